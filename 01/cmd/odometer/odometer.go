@@ -0,0 +1,96 @@
+// Command odometer runs day 01 instructions on a chain of geared dials, where
+// each dial turns the next whenever it wraps past zero. Instructions name the
+// dial they turn, like A:R20 or C:L5, in a day 01 program with repeat blocks
+// and # comments:
+//
+//	go run ./01/cmd/odometer -sizes 100,10,10 -starts 50,0,0 input.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/01/dial"
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func main() {
+	sizes := flag.String("sizes", "100,100,100", "comma-separated sizes of the dials, first to last")
+	starts := flag.String("starts", "50,0,0", "comma-separated start positions of the dials")
+	target := flag.Int("target", 0, "position whose visits are counted on every dial")
+	flag.Parse()
+
+	filename := "input.txt"
+	if flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+
+	sizeList, err := intList(*sizes)
+	if err != nil {
+		log.Fatal("Invalid -sizes: ", err)
+	}
+	startList, err := intList(*starts)
+	if err != nil {
+		log.Fatal("Invalid -starts: ", err)
+	}
+	if len(startList) != len(sizeList) {
+		log.Fatalf("Got %d start positions for %d dials", len(startList), len(sizeList))
+	}
+
+	dials := make([]*dial.Dial, len(sizeList))
+	labels := make(map[string]int)
+	for i := range sizeList {
+		dials[i], err = dial.New(sizeList[i], startList[i], *target)
+		if err != nil {
+			log.Fatalf("Dial %s: %v", dial.Label(i), err)
+		}
+		labels[dial.Label(i)] = i
+	}
+	odometer := dial.NewOdometer(dials...)
+
+	f, err := os.Open(filename)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	landings := make([]int, len(dials))
+	passes := make([]int, len(dials))
+
+	err = dial.ExpandAddressed(bufio.NewScanner(f), func(in dial.Instruction) error {
+		d, ok := labels[in.Dial]
+		if !ok {
+			return parse.Errorf(in.Line, in.String(), "no dial %s", in.Dial)
+		}
+
+		for j, counts := range odometer.Apply(d, in) {
+			landings[j] += counts[0].Landings
+			passes[j] += counts[0].Passes
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i, d := range dials {
+		fmt.Printf("Dial %s: position %d, landings %d, passes %d\n", dial.Label(i), d.Position(), landings[i], passes[i])
+	}
+}
+
+func intList(s string) ([]int, error) {
+	var list []int
+	for _, field := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+	return list, nil
+}
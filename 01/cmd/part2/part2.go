@@ -0,0 +1,9 @@
+// Command part2 counts every click of the day 01 rotations that points the
+// dial at the target. See package cli for the flags.
+package main
+
+import "github.com/dfryer1193/AoC-2025/01/internal/cli"
+
+func main() {
+	cli.Main(2)
+}
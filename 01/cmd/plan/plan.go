@@ -0,0 +1,58 @@
+// Command plan works out the least dial movement that enters a combination,
+// and prints it as a day 01 instruction file:
+//
+//	go run ./01/cmd/plan -alternate -pass-target 12 35 7 > plan.txt
+//	go run ./01/cmd/part1 plan.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/01/dial"
+)
+
+func main() {
+	size := flag.Int("size", 100, "number of positions on the dial")
+	start := flag.Int("start", 50, "position the dial starts at")
+	target := flag.Int("target", 0, "position that -pass-target refers to")
+	alternate := flag.Bool("alternate", false, "dial each number in the opposite direction to the last")
+	passTarget := flag.Bool("pass-target", false, "pass the target at least once on the way to each number after the first")
+	flag.Parse()
+
+	// Numbers may be given as separate arguments or as 12-35-7 or 12,35,7
+	var combination []int
+	for _, arg := range flag.Args() {
+		for _, field := range strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || r == '-' }) {
+			n, err := strconv.Atoi(field)
+			if err != nil {
+				log.Fatalf("Invalid combination number %q", field)
+			}
+			combination = append(combination, n)
+		}
+	}
+	if len(combination) == 0 {
+		log.Fatal("Please provide a combination, like 12 35 7")
+	}
+
+	d, err := dial.New(*size, *start, *target)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	plan, err := d.Plan(combination, dial.PlanOptions{Alternate: *alternate, PassTargets: *passTarget})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	total := 0
+	for _, in := range plan {
+		fmt.Println(in)
+		total += max(in.N, -in.N)
+	}
+	fmt.Fprintln(os.Stderr, "Total movement:", total)
+}
@@ -0,0 +1,207 @@
+package dial
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/dfryer1193/AoC-2025/internal/report"
+)
+
+// AuditOptions holds where to write a run's audit. Each is a report: JSON
+// for a .json file, CSV otherwise.
+type AuditOptions struct {
+	Audit     string // per-instruction log, empty to skip
+	Histogram string // per-position histogram, empty to skip
+}
+
+// RegisterAuditFlags adds the -audit and -histogram flags to the default flag
+// set and returns the AuditOptions they populate.
+func RegisterAuditFlags() *AuditOptions {
+	o := &AuditOptions{}
+	flag.StringVar(&o.Audit, "audit", "", "write a per-instruction audit (CSV, or JSON for .json) to this file")
+	flag.StringVar(&o.Histogram, "histogram", "", "write how often each position was landed on or swept over (CSV, or JSON for .json) to this file")
+	return o
+}
+
+// Entry is the audit of one instruction.
+type Entry struct {
+	Index       int    `json:"index"` // 1-based, after repeats are expanded
+	Line        int    `json:"line"`
+	Instruction string `json:"instruction"`
+	Start       int    `json:"start"`
+	End         int    `json:"end"`
+	Passes      int    `json:"passes"`
+	Landings    int    `json:"landings"`
+	Stops       int    `json:"stops"`
+}
+
+// Auditor records every instruction of a run. Like anim.Recorder, one with
+// nothing to write discards everything, so callers can record
+// unconditionally.
+type Auditor struct {
+	log   *report.Writer // streamed as instructions run, so costs no memory
+	hist  *histogram
+	index int
+}
+
+// Open creates an Auditor for a dial of size, for the outputs requested in o.
+func (o *AuditOptions) Open(size int) (*Auditor, error) {
+	a := &Auditor{}
+	if o.Audit != "" {
+		l, err := newAuditLog(o.Audit)
+		if err != nil {
+			return nil, err
+		}
+		a.log = l
+	}
+	if o.Histogram != "" {
+		a.hist = newHistogram(o.Histogram, size)
+	}
+	return a, nil
+}
+
+// Enabled reports whether the Auditor writes anything.
+func (a *Auditor) Enabled() bool {
+	return a.log != nil || a.hist != nil
+}
+
+// Record audits one instruction that moved the pointer from start to end,
+// with the counts it made for the dial's first target.
+func (a *Auditor) Record(in Instruction, start, end int, c Counts) error {
+	a.index++
+	if a.hist != nil {
+		a.hist.record(in, start, end)
+	}
+	if a.log != nil {
+		return writeEntry(a.log, Entry{
+			Index:       a.index,
+			Line:        in.Line,
+			Instruction: in.String(),
+			Start:       start,
+			End:         end,
+			Passes:      c.Passes,
+			Landings:    c.Landings,
+			Stops:       c.Stops,
+		})
+	}
+	return nil
+}
+
+// Close finishes the audit log and writes the histogram.
+func (a *Auditor) Close() error {
+	var err error
+	if a.log != nil {
+		err = a.log.Close()
+	}
+	if a.hist != nil {
+		if herr := a.hist.write(); err == nil {
+			err = herr
+		}
+	}
+	return err
+}
+
+// newAuditLog starts the per-instruction log at path.
+func newAuditLog(path string) (*report.Writer, error) {
+	return report.Create(path, "index", "line", "instruction", "start", "end", "passes", "landings", "stops")
+}
+
+func writeEntry(w *report.Writer, e Entry) error {
+	if w.JSON() {
+		return w.Item(e)
+	}
+	return w.Row(
+		strconv.Itoa(e.Index), strconv.Itoa(e.Line), e.Instruction,
+		strconv.Itoa(e.Start), strconv.Itoa(e.End),
+		strconv.Itoa(e.Passes), strconv.Itoa(e.Landings), strconv.Itoa(e.Stops),
+	)
+}
+
+// histogram counts, for every position, the rotations that left the
+// pointer there and the clicks that swept over it without stopping.
+type histogram struct {
+	path   string
+	landed []int
+	turns  int   // full turns, which sweep every position once
+	sweeps []int // difference array of partial sweeps, one longer than the dial
+}
+
+func newHistogram(path string, size int) *histogram {
+	return &histogram{path: path, landed: make([]int, size), sweeps: make([]int, size+1)}
+}
+
+func (h *histogram) record(in Instruction, start, end int) {
+	// Setting the position moves the pointer without turning the dial, so it
+	// neither lands nor sweeps
+	if in.Kind == Set {
+		return
+	}
+
+	// Every rotation stops where it ends, as Counts.Stops has it
+	size := len(h.landed)
+	h.landed[end]++
+	if in.N == 0 {
+		return
+	}
+
+	// A rotation of n clicks covers start+1 ... start+n (or the same to the
+	// left): whole turns plus a partial run, and the last click isn't a sweep
+	n := max(in.N, -in.N)
+	h.turns += n / size
+	if r := n % size; r > 0 {
+		from := start + 1
+		if in.N < 0 {
+			from = start - r
+		}
+		h.sweep(Move(from, 0, size), r)
+	}
+	h.sweeps[end]--
+	h.sweeps[end+1]++
+}
+
+// sweep adds one to the n positions from from onwards, wrapping around.
+func (h *histogram) sweep(from, n int) {
+	size := len(h.landed)
+	to := from + n
+	if to > size {
+		h.sweeps[from]++
+		h.sweeps[size]--
+		from, to = 0, to-size
+	}
+	h.sweeps[from]++
+	h.sweeps[to]--
+}
+
+func (h *histogram) write() error {
+	swept := make([]int, len(h.landed))
+	run := h.turns
+	for p := range swept {
+		run += h.sweeps[p]
+		swept[p] = run
+	}
+
+	w, err := report.Create(h.path, "position", "landed", "swept")
+	if err != nil {
+		return err
+	}
+	for p := range swept {
+		if w.JSON() {
+			err = w.Item(struct {
+				Position int `json:"position"`
+				Landed   int `json:"landed"`
+				Swept    int `json:"swept"`
+			}{p, h.landed[p], swept[p]})
+		} else {
+			err = w.Row(strconv.Itoa(p), strconv.Itoa(h.landed[p]), strconv.Itoa(swept[p]))
+		}
+		if err != nil {
+			w.Close()
+			return fmt.Errorf("writing histogram: %w", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("writing histogram: %w", err)
+	}
+	return nil
+}
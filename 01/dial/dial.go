@@ -0,0 +1,132 @@
+// Package dial simulates the safe dial from day 01: a ring of positions
+// numbered from 0 with a pointer that is rotated left or right one click at a
+// time, and a set of target positions whose visits are counted.
+package dial
+
+import (
+	"fmt"
+
+	"github.com/dfryer1193/AoC-2025/internal/anim"
+)
+
+// Dial is a dial of a fixed size with its pointer at some position.
+type Dial struct {
+	size     int
+	position int
+	targets  []int
+}
+
+// Counts records how one rotation met one target.
+type Counts struct {
+	Passes   int // clicks that moved through the target without stopping on it
+	Landings int // 1 if the rotation's last click stopped on the target
+	Stops    int // 1 if the rotation, even an empty one, left the pointer on the target, as part 1 counts
+}
+
+// Clicks returns how many clicks of the rotation pointed at the target.
+func (c Counts) Clicks() int {
+	return c.Passes + c.Landings
+}
+
+// New returns a dial of size positions with the pointer at start, counting
+// visits to each of targets.
+func New(size, start int, targets ...int) (*Dial, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("dial size must be positive, got %d", size)
+	}
+	if start < 0 || start >= size {
+		return nil, fmt.Errorf("start %d is not on a dial of size %d", start, size)
+	}
+	for _, t := range targets {
+		if t < 0 || t >= size {
+			return nil, fmt.Errorf("target %d is not on a dial of size %d", t, size)
+		}
+	}
+
+	return &Dial{size: size, position: start, targets: append([]int(nil), targets...)}, nil
+}
+
+// Size returns the number of positions on the dial.
+func (d *Dial) Size() int {
+	return d.size
+}
+
+// Position returns where the pointer is.
+func (d *Dial) Position() int {
+	return d.position
+}
+
+// Targets returns the positions whose visits are counted, in the order their
+// counts are reported.
+func (d *Dial) Targets() []int {
+	return d.targets
+}
+
+// Rotate turns the dial steps clicks, to the right (towards higher numbers)
+// for positive steps and to the left for negative ones. It returns the new
+// position and, for each target, how often the rotation passed or landed on
+// it. A zero-step rotation makes no clicks, so it neither passes nor lands,
+// even when the pointer already rests on a target, but it still stops there.
+func (d *Dial) Rotate(steps int) (int, []Counts) {
+	counts := make([]Counts, len(d.targets))
+	for i, t := range d.targets {
+		counts[i] = d.countsFor(t, steps)
+	}
+
+	d.position = Move(d.position, steps, d.size)
+	return d.position, counts
+}
+
+// countsFor works out how a rotation of steps from the current position
+// meets target, without making the clicks one by one.
+func (d *Dial) countsFor(target, steps int) Counts {
+	var c Counts
+	if Move(d.position, steps, d.size) == target {
+		c.Stops = 1
+	}
+	if steps == 0 {
+		return c
+	}
+
+	clicks := Hits(d.position, steps, target, d.size)
+	c.Landings = c.Stops
+	c.Passes = clicks - c.Landings
+	return c
+}
+
+// Move returns where a pointer at position on a dial of size ends up after
+// steps clicks, right for positive and left for negative.
+func Move(position, steps, size int) int {
+	return ((position+steps%size)%size + size) % size
+}
+
+// Hits counts the clicks of a rotation of steps from position that point at
+// target on a dial of size, including the last one.
+func Hits(position, steps, target, size int) int {
+	// The first click to reach the target is the distance to it in the
+	// direction of travel, a full turn if it is where we start
+	var first, n int
+	if steps >= 0 {
+		first, n = Move(target, -position, size), steps
+	} else {
+		first, n = Move(position, -target, size), -steps
+	}
+	if first == 0 {
+		first = size
+	}
+
+	if n < first {
+		return 0
+	}
+	return (n-first)/size + 1
+}
+
+// Frame draws the dial for an animation, with its first target drawn as '0'
+// and the pointer as '@'.
+func (d *Dial) Frame() []string {
+	marks := map[int]rune{d.position: '@'}
+	if len(d.targets) > 0 && d.targets[0] != d.position {
+		marks[d.targets[0]] = '0'
+	}
+	return anim.Ring(d.size, marks)
+}
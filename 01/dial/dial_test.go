@@ -0,0 +1,110 @@
+package dial
+
+import "testing"
+
+const huge = 1_000_000_000_000 // a multiple of the size, so it adds whole turns
+
+func TestMove(t *testing.T) {
+	tests := []struct {
+		name                  string
+		position, steps, size int
+		want                  int
+	}{
+		{"no steps", 50, 0, 100, 50},
+		{"right to zero", 99, 1, 100, 0},
+		{"left from zero", 0, -1, 100, 99},
+		{"right full turn from zero", 0, 100, 100, 0},
+		{"left full turn from zero", 0, -100, 100, 0},
+		{"right multiple turns", 0, 300, 100, 0},
+		{"left multiple turns", 0, -300, 100, 0},
+		{"right past zero", 50, 150, 100, 0},
+		{"left past zero", 50, -150, 100, 0},
+		{"right huge", 50, huge + 5, 100, 55},
+		{"left huge", 50, -huge - 5, 100, 45},
+		{"single position", 0, 7, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Move(tt.position, tt.steps, tt.size); got != tt.want {
+				t.Errorf("Move(%d, %d, %d) = %d, want %d", tt.position, tt.steps, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHits(t *testing.T) {
+	tests := []struct {
+		name            string
+		position, steps int
+		want            int
+	}{
+		{"no steps on zero", 0, 0, 0},
+		{"right from zero short of a turn", 0, 99, 0},
+		{"left from zero short of a turn", 0, -99, 0},
+		{"right full turn from zero", 0, 100, 1},
+		{"left full turn from zero", 0, -100, 1},
+		{"right two turns from zero", 0, 200, 2},
+		{"left two turns from zero", 0, -200, 2},
+		{"right short of zero", 50, 49, 0},
+		{"left short of zero", 50, -49, 0},
+		{"right onto zero", 50, 50, 1},
+		{"left onto zero", 50, -50, 1},
+		{"right through zero many times", 50, 1000, 10},
+		{"left through zero many times", 50, -1000, 10},
+		{"right huge", 50, huge, huge / 100},
+		{"left huge", 50, -huge, huge / 100},
+		{"right huge from zero", 0, huge, huge / 100},
+		{"left huge from zero", 0, -huge, huge / 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Hits(tt.position, tt.steps, 0, 100); got != tt.want {
+				t.Errorf("Hits(%d, %d, 0, 100) = %d, want %d", tt.position, tt.steps, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	tests := []struct {
+		name         string
+		start, steps int
+		position     int
+		want         Counts
+	}{
+		{"no steps on zero", 0, 0, 0, Counts{Stops: 1}},
+		{"no steps off zero", 50, 0, 50, Counts{}},
+		{"right off zero", 0, 1, 1, Counts{}},
+		{"left off zero", 0, -1, 99, Counts{}},
+		{"right onto zero", 99, 1, 0, Counts{Landings: 1, Stops: 1}},
+		{"left onto zero", 1, -1, 0, Counts{Landings: 1, Stops: 1}},
+		{"right full turn from zero", 0, 100, 0, Counts{Landings: 1, Stops: 1}},
+		{"left full turn from zero", 0, -100, 0, Counts{Landings: 1, Stops: 1}},
+		{"right turns and a half from zero", 0, 250, 50, Counts{Passes: 2}},
+		{"left turns and a half from zero", 0, -250, 50, Counts{Passes: 2}},
+		{"right through and onto zero", 50, 150, 0, Counts{Passes: 1, Landings: 1, Stops: 1}},
+		{"left through and onto zero", 50, -150, 0, Counts{Passes: 1, Landings: 1, Stops: 1}},
+		{"right huge", 50, huge, 50, Counts{Passes: huge / 100}},
+		{"left huge", 50, -huge, 50, Counts{Passes: huge / 100}},
+		{"right huge onto zero", 50, huge + 50, 0, Counts{Passes: huge / 100, Landings: 1, Stops: 1}},
+		{"left huge onto zero", 50, -huge - 50, 0, Counts{Passes: huge / 100, Landings: 1, Stops: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := New(100, tt.start, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			position, counts := d.Rotate(tt.steps)
+			if position != tt.position || counts[0] != tt.want {
+				t.Errorf("rotating %d from %d = %d, %+v, want %d, %+v", tt.steps, tt.start, position, counts[0], tt.position, tt.want)
+			}
+			if d.Position() != position {
+				t.Errorf("Position() = %d after rotating to %d", d.Position(), position)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package dial
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// maxFuzzInstructions bounds how far a fuzzed program is expanded, since a
+// few nested repeats can run for longer than any fuzz input is worth.
+const maxFuzzInstructions = 1 << 12
+
+var errTooLong = errors.New("program too long to fuzz")
+
+// FuzzParseProgram checks that Expand either rejects a program with a
+// *parse.Error or expands it into instructions that the dial and a Summary
+// count the same way.
+func FuzzParseProgram(f *testing.F) {
+	example, err := os.ReadFile("../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		var program []Instruction
+		err := Expand(bufio.NewScanner(bytes.NewReader(input)), func(in Instruction) error {
+			if len(program) == maxFuzzInstructions {
+				return errTooLong
+			}
+			program = append(program, in)
+			return nil
+		})
+		if errors.Is(err, errTooLong) {
+			t.Skip(err)
+		}
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		d, err := New(100, 50, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var want Totals
+		for _, in := range program {
+			_, counts := d.Apply(in)
+			want.Clicks += counts[0].Clicks()
+			want.Stops += counts[0].Stops
+		}
+
+		end, totals := Summarize(100, []int{0}, program).Apply(50)
+		if end != d.Position() || totals[0] != want {
+			t.Errorf("summary ends at %d with %+v, running the program ends at %d with %+v", end, totals[0], d.Position(), want)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package dial
+
+import "fmt"
+
+// History is an indexed record of a run of rotations. It answers where the
+// pointer was after any instruction, and how often it landed on or passed a
+// target over any stretch of instructions, without replaying the run.
+//
+// Instructions are numbered from 1, after repeats are expanded. Counts are
+// kept as prefix sums, so every query takes constant time.
+type History struct {
+	targets   []int
+	positions []int   // positions[i] is where instruction i left the pointer; [0] is the start
+	stops     [][]int // per target, stops over instructions 1..i
+	passes    [][]int // per target, passes over instructions 1..i
+}
+
+// NewHistory runs program on d, recording every instruction.
+func NewHistory(d *Dial, program []Instruction) *History {
+	h := &History{
+		targets:   d.Targets(),
+		positions: make([]int, 1, len(program)+1),
+		stops:     make([][]int, len(d.Targets())),
+		passes:    make([][]int, len(d.Targets())),
+	}
+	h.positions[0] = d.Position()
+	for t := range h.targets {
+		h.stops[t] = make([]int, 1, len(program)+1)
+		h.passes[t] = make([]int, 1, len(program)+1)
+	}
+
+	for i, in := range program {
+		position, counts := d.Apply(in)
+		h.positions = append(h.positions, position)
+		for t, c := range counts {
+			h.stops[t] = append(h.stops[t], h.stops[t][i]+c.Stops)
+			h.passes[t] = append(h.passes[t], h.passes[t][i]+c.Passes)
+		}
+	}
+
+	return h
+}
+
+// Len returns the number of instructions recorded.
+func (h *History) Len() int {
+	return len(h.positions) - 1
+}
+
+// Position returns where the pointer was after instruction i, or at the start
+// for i = 0.
+func (h *History) Position(i int) (int, error) {
+	if i < 0 || i > h.Len() {
+		return 0, fmt.Errorf("instruction %d out of range 0-%d", i, h.Len())
+	}
+	return h.positions[i], nil
+}
+
+// Landings returns how many of instructions i through j stopped on the
+// target with the given index in Targets. Like part 1, it counts rotations of
+// zero that rest on the target.
+func (h *History) Landings(target, i, j int) (int, error) {
+	if err := h.check(target, i, j); err != nil {
+		return 0, err
+	}
+	return h.stops[target][j] - h.stops[target][i-1], nil
+}
+
+// Passes returns how many times instructions i through j moved through the
+// target with the given index in Targets without stopping on it.
+func (h *History) Passes(target, i, j int) (int, error) {
+	if err := h.check(target, i, j); err != nil {
+		return 0, err
+	}
+	return h.passes[target][j] - h.passes[target][i-1], nil
+}
+
+func (h *History) check(target, i, j int) error {
+	if target < 0 || target >= len(h.targets) {
+		return fmt.Errorf("no target %d", target)
+	}
+	if i < 1 || j > h.Len() || i > j {
+		return fmt.Errorf("instructions %d-%d out of range 1-%d", i, j, h.Len())
+	}
+	return nil
+}
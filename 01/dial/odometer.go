@@ -0,0 +1,96 @@
+package dial
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// Odometer is a chain of dials in which each dial turns the next one click
+// every time it wraps past zero: forward when it rolls over from the top
+// position to zero, back when it rolls under from zero to the top. The last
+// dial's wraps are lost, as on a real odometer.
+type Odometer struct {
+	dials []*Dial
+}
+
+// NewOdometer chains dials in order, the first driving the second and so on.
+func NewOdometer(dials ...*Dial) *Odometer {
+	return &Odometer{dials: dials}
+}
+
+// Dials returns the dials of the chain in order.
+func (o *Odometer) Dials() []*Dial {
+	return o.dials
+}
+
+// Apply carries out in on dial i and lets the carries ripple down the chain.
+// It returns the counts every dial's movement made for each of its targets,
+// indexed by dial then target.
+func (o *Odometer) Apply(i int, in Instruction) [][]Counts {
+	counts := make([][]Counts, len(o.dials))
+	for j, d := range o.dials {
+		counts[j] = make([]Counts, len(d.targets))
+	}
+
+	if in.Kind == Set {
+		o.dials[i].Apply(in) // Setting a wheel turns nothing, so nothing carries
+		return counts
+	}
+
+	steps := in.N
+	for ; i < len(o.dials) && steps != 0; i++ {
+		d := o.dials[i]
+		carry := Wraps(d.position, steps, d.size)
+		_, counts[i] = d.Rotate(steps)
+		steps = carry
+	}
+	return counts
+}
+
+// Wraps returns how many times a rotation of steps from position on a dial of
+// size rolls over: positive for each click from size-1 onto 0 going right,
+// negative for each click from 0 onto size-1 going left.
+func Wraps(position, steps, size int) int {
+	if steps >= 0 {
+		return Hits(position, steps, 0, size)
+	}
+	return -Hits(position, steps, size-1, size)
+}
+
+// ExpandAddressed is Expand for odometer programs, whose instructions name
+// the dial they turn, like B:R20. Repeat blocks and comments work as in
+// Expand, as in 3x(A:R5 B:L1), and each instruction's Dial is its label.
+func ExpandAddressed(scanner *bufio.Scanner, fn func(Instruction) error) error {
+	return expandWith(scanner, func(lineNum int, token string) (Instruction, error) {
+		label, in, err := ParseAddressed(lineNum, token)
+		in.Dial = label
+		return in, err
+	}, fn)
+}
+
+// ParseAddressed parses an odometer instruction like "B:R20", returning the
+// label of the dial it is for and the instruction.
+func ParseAddressed(lineNum int, token string) (string, Instruction, error) {
+	label, rest, ok := strings.Cut(token, ":")
+	if !ok || label == "" {
+		return "", Instruction{}, parse.Errorf(lineNum, token, "expected an instruction addressed to a dial, like A:R20")
+	}
+
+	in, err := parseInstruction(lineNum, rest)
+	if err != nil {
+		return "", Instruction{}, err
+	}
+	return label, in, nil
+}
+
+// Label names the i-th dial of a chain: A, B, ..., Z, then AA, AB and so on.
+func Label(i int) string {
+	label := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		label = fmt.Sprintf("%c", 'A'+(i-1)%26) + label
+	}
+	return label
+}
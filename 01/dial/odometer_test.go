@@ -0,0 +1,154 @@
+package dial
+
+import (
+	"bufio"
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func TestWraps(t *testing.T) {
+	tests := []struct {
+		name                  string
+		position, steps, size int
+		want                  int
+	}{
+		{"no steps", 0, 0, 10, 0},
+		{"right onto zero", 99, 1, 100, 1},
+		{"right off zero", 0, 1, 100, 0},
+		{"right short of zero", 50, 49, 100, 0},
+		{"right exactly to zero", 50, 50, 100, 1},
+		{"right full turn from zero", 0, 100, 100, 1},
+		{"right several turns", 50, 250, 100, 3},
+		{"left off zero", 0, -1, 100, -1},
+		{"left onto zero", 1, -1, 100, 0},
+		{"left full turn from zero", 0, -100, 100, -1},
+		{"left several turns", 50, -151, 100, -2},
+		{"one-position dial", 0, 7, 1, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Wraps(tt.position, tt.steps, tt.size); got != tt.want {
+				t.Errorf("Wraps(%d, %d, %d) = %d, want %d", tt.position, tt.steps, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+// newOdometer chains dials of the given sizes, all starting at 0 and
+// counting visits to 0.
+func newOdometer(t *testing.T, sizes ...int) *Odometer {
+	t.Helper()
+	dials := make([]*Dial, len(sizes))
+	for i, size := range sizes {
+		d, err := New(size, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dials[i] = d
+	}
+	return NewOdometer(dials...)
+}
+
+func positions(o *Odometer) []int {
+	p := make([]int, len(o.Dials()))
+	for i, d := range o.Dials() {
+		p[i] = d.Position()
+	}
+	return p
+}
+
+func TestOdometerApply(t *testing.T) {
+	o := newOdometer(t, 10, 10, 10)
+
+	steps := []struct {
+		dial   int
+		in     Instruction
+		want   []int
+		counts []Counts // for each dial's target
+	}{
+		{0, Instruction{Kind: Rotate, N: 25}, []int{5, 2, 0}, []Counts{{Passes: 2}, {}, {}}},
+		{0, Instruction{Kind: Rotate, N: -6}, []int{9, 1, 0}, []Counts{{Passes: 1}, {}, {}}},
+		{0, Instruction{Kind: Rotate, N: 1}, []int{0, 2, 0}, []Counts{{Landings: 1, Stops: 1}, {}, {}}},
+		{1, Instruction{Kind: Rotate, N: 95}, []int{0, 7, 9}, []Counts{{}, {Passes: 9}, {}}},
+		{2, Instruction{Kind: Rotate, N: 3}, []int{0, 7, 2}, []Counts{{}, {}, {Passes: 1}}},
+		{0, Instruction{Kind: Set, N: 4}, []int{4, 7, 2}, []Counts{{}, {}, {}}},
+		{1, Instruction{Kind: Rotate, N: 0}, []int{4, 7, 2}, []Counts{{}, {}, {}}},
+	}
+
+	for _, s := range steps {
+		counts := o.Apply(s.dial, s.in)
+		got := positions(o)
+		if !slices.Equal(got, s.want) {
+			t.Fatalf("after %s on dial %s: positions %v, want %v", s.in, Label(s.dial), got, s.want)
+		}
+		for i := range counts {
+			if counts[i][0] != s.counts[i] {
+				t.Errorf("after %s on dial %s: dial %s counts %+v, want %+v", s.in, Label(s.dial), Label(i), counts[i][0], s.counts[i])
+			}
+		}
+	}
+}
+
+// TestOdometerCarries checks that wraps carry like the digits of a number in
+// a mixed radix: whatever is turned, the reading of the dials changes by the
+// clicks turned times the value of the dial turned.
+func TestOdometerCarries(t *testing.T) {
+	sizes := []int{7, 3, 10, 4}
+	o := newOdometer(t, sizes...)
+	total := 1
+	for _, size := range sizes {
+		total *= size
+	}
+
+	r := rand.New(rand.NewPCG(2025, 1))
+	want := 0
+	for range 1000 {
+		i := r.IntN(len(sizes))
+		steps := r.IntN(201) - 100
+		o.Apply(i, Instruction{Kind: Rotate, N: steps})
+
+		unit := 1
+		for _, size := range sizes[:i] {
+			unit *= size
+		}
+		want = Move(want, steps*unit, total)
+
+		got, unit := 0, 1
+		for j, p := range positions(o) {
+			got += p * unit
+			unit *= sizes[j]
+		}
+		if got != want {
+			t.Fatalf("after turning dial %s by %d: reading %d (positions %v), want %d", Label(i), steps, got, positions(o), want)
+		}
+	}
+}
+
+func TestExpandAddressed(t *testing.T) {
+	src := "2x(A:R5 B:L1) # comment\n\nC:S3 A:L0\n"
+	var got []string
+	err := ExpandAddressed(bufio.NewScanner(strings.NewReader(src)), func(in Instruction) error {
+		got = append(got, in.String())
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"A:R5", "B:L1", "A:R5", "B:L1", "C:S3", "A:R0"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("expanded %q to %v, want %v", src, got, want)
+	}
+
+	for _, src := range []string{"R5\n", ":R5\n", "A:X5\n", "2x(A:R5\n"} {
+		err := ExpandAddressed(bufio.NewScanner(strings.NewReader(src)), func(Instruction) error { return nil })
+		if !errors.As(err, new(*parse.Error)) {
+			t.Errorf("expanding %q: error %v, want a *parse.Error", src, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package dial
+
+import (
+	"bufio"
+	"io"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// ParseRotation parses a line like "L68" into a signed step count: negative
+// for L, positive for R.
+func ParseRotation(lineNum int, line string) (int, error) {
+	if len(line) < 2 {
+		return 0, parse.Errorf(lineNum, line, "expected a rotation like L68")
+	}
+
+	direction := line[0]
+	if direction != 'L' && direction != 'R' {
+		return 0, parse.Errorf(lineNum, line, "direction must be L or R")
+	}
+
+	steps, err := parse.Int(lineNum, line[1:])
+	if err != nil {
+		return 0, err
+	}
+	if steps < 0 {
+		return 0, parse.Errorf(lineNum, line, "negative step count")
+	}
+
+	if direction == 'L' {
+		return -steps, nil
+	}
+	return steps, nil
+}
+
+// ReadProgram parses and expands a whole program from r.
+func ReadProgram(r io.Reader) ([]Instruction, error) {
+	var program []Instruction
+	err := Expand(bufio.NewScanner(r), func(in Instruction) error {
+		program = append(program, in)
+		return nil
+	})
+	return program, err
+}
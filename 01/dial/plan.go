@@ -0,0 +1,116 @@
+package dial
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// PlanOptions are the rules a combination must be entered by.
+type PlanOptions struct {
+	// Alternate requires each number after the first to be dialed in the
+	// opposite direction to the one before it. The first has nothing to
+	// alternate with, so it may be where the pointer already is.
+	Alternate bool
+	// PassTargets requires the rotation to each number after the first to
+	// move through every target at least once without stopping on it.
+	PassTargets bool
+}
+
+// Plan finds the rotations with the least total movement that stop the
+// pointer on each number of combination in turn, one rotation per number,
+// starting from the dial's current position. The dial itself is not turned.
+func (d *Dial) Plan(combination []int, opts PlanOptions) ([]Instruction, error) {
+	for _, n := range combination {
+		if n < 0 || n >= d.size {
+			return nil, fmt.Errorf("%d is not on a dial of size %d", n, d.size)
+		}
+	}
+	if len(combination) == 0 {
+		return nil, errors.New("empty combination")
+	}
+
+	// cost[dir] is the least movement to enter the numbers so far with the
+	// last one dialed in direction dir; choice remembers the rotations
+	const right, left = 0, 1
+	cost := [2]int{0, 0}
+	choice := make([][2]Instruction, len(combination))
+	from := make([][2]int, len(combination)) // direction of the previous rotation
+
+	position := d.position
+	for i, n := range combination {
+		var next [2]int
+		for dir := range 2 {
+			steps := d.shortestTo(position, n, dir == left, opts.Alternate && i > 0, opts.PassTargets && i > 0)
+			next[dir] = math.MaxInt
+			for prev := range 2 {
+				if i > 0 && opts.Alternate && prev == dir {
+					continue
+				}
+				if c := cost[prev] + abs(steps); c < next[dir] {
+					next[dir], from[i][dir] = c, prev
+				}
+			}
+			choice[i][dir] = Instruction{Kind: Rotate, N: steps}
+		}
+		cost, position = next, n
+	}
+
+	dir := right
+	if cost[left] < cost[right] {
+		dir = left
+	}
+	plan := make([]Instruction, len(combination))
+	for i := len(combination) - 1; i >= 0; i-- {
+		plan[i] = choice[i][dir]
+		dir = from[i][dir]
+	}
+
+	return plan, nil
+}
+
+// shortestTo returns the signed steps of the shortest rotation from position
+// to n in one direction, optionally one that passes every target. A rotation
+// that must turn, because its direction counts, goes a full turn rather than
+// staying put, since a rotation of zero has no direction.
+func (d *Dial) shortestTo(position, n int, leftward, mustTurn, passTargets bool) int {
+	steps := Move(n, -position, d.size)
+	if leftward {
+		steps = Move(position, -n, d.size)
+	}
+	if steps == 0 && mustTurn {
+		steps = d.size
+	}
+
+	// Two extra turns always suffice: every target is clicked through twice
+	for turns := 0; ; turns++ {
+		s := steps + turns*d.size
+		if leftward {
+			s = -s
+		}
+		if !passTargets || d.passesAll(position, s) {
+			return s
+		}
+	}
+}
+
+func (d *Dial) passesAll(position, steps int) bool {
+	end := Move(position, steps, d.size)
+	for _, t := range d.targets {
+		passes := Hits(position, steps, t, d.size)
+		if end == t && steps != 0 {
+			passes-- // The last click stops on it
+		}
+		if passes < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
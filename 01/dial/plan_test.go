@@ -0,0 +1,138 @@
+package dial
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestPlanMatchesBruteForce(t *testing.T) {
+	tests := []PlanOptions{
+		{},
+		{Alternate: true},
+		{PassTargets: true},
+		{Alternate: true, PassTargets: true},
+	}
+
+	for _, opts := range tests {
+		t.Run(fmt.Sprintf("%+v", opts), func(t *testing.T) {
+			r := rand.New(rand.NewPCG(2025, 1))
+			for range 500 {
+				size := 1 + r.IntN(8)
+				start := r.IntN(size)
+				targets := []int{r.IntN(size)}
+				if r.IntN(2) == 0 {
+					targets = append(targets, r.IntN(size))
+				}
+				combination := make([]int, 1+r.IntN(4))
+				for i := range combination {
+					combination[i] = r.IntN(size)
+				}
+				// Start on the first number now and then
+				if r.IntN(4) == 0 {
+					start = combination[0]
+				}
+
+				d, err := New(size, start, targets...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				plan, err := d.Plan(combination, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				desc := fmt.Sprintf("size %d from %d, targets %v, combination %v", size, start, targets, combination)
+				if !validPlan(d, combination, opts, plan) {
+					t.Fatalf("%s: plan %v breaks the rules", desc, plan)
+				}
+				if got, want := movement(plan), bestMovement(d, combination, opts); got != want {
+					t.Fatalf("%s: plan %v moves %d, the best moves %d", desc, plan, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanAlternateFirstNumber(t *testing.T) {
+	d, err := New(100, 50, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plan, err := d.Plan([]int{50, 60}, PlanOptions{Alternate: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := movement(plan); got != 10 {
+		t.Errorf("plan %v moves %d, want 10", plan, got)
+	}
+}
+
+func TestPlanErrors(t *testing.T) {
+	d, err := New(10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, combination := range [][]int{nil, {10}, {3, -1}} {
+		if plan, err := d.Plan(combination, PlanOptions{}); err == nil {
+			t.Errorf("Plan(%v) = %v, want an error", combination, plan)
+		}
+	}
+}
+
+func movement(plan []Instruction) int {
+	total := 0
+	for _, in := range plan {
+		total += abs(in.N)
+	}
+	return total
+}
+
+// validPlan checks that plan stops on each number in turn and keeps to opts.
+// A rotation of zero has no direction, so with Alternate only the first
+// rotation may be one.
+func validPlan(d *Dial, combination []int, opts PlanOptions, plan []Instruction) bool {
+	if len(plan) != len(combination) {
+		return false
+	}
+	position := d.Position()
+	for i, in := range plan {
+		if in.Kind != Rotate || Move(position, in.N, d.Size()) != combination[i] {
+			return false
+		}
+		if i > 0 && opts.Alternate && (in.N == 0 || plan[i-1].N*in.N > 0) {
+			return false
+		}
+		if i > 0 && opts.PassTargets && !d.passesAll(position, in.N) {
+			return false
+		}
+		position = combination[i]
+	}
+	return true
+}
+
+// bestMovement tries every plan whose rotations make at most three turns and
+// returns the least movement of those that keep to opts.
+func bestMovement(d *Dial, combination []int, opts PlanOptions) int {
+	best := -1
+	plan := make([]Instruction, len(combination))
+	var try func(i, position int)
+	try = func(i, position int) {
+		if i == len(combination) {
+			if m := movement(plan); validPlan(d, combination, opts, plan) && (best < 0 || m < best) {
+				best = m
+			}
+			return
+		}
+		right := Move(combination[i], -position, d.Size())
+		left := Move(position, -combination[i], d.Size())
+		for turns := range 4 {
+			for _, steps := range []int{right + turns*d.Size(), -(left + turns*d.Size())} {
+				plan[i] = Instruction{Kind: Rotate, N: steps}
+				try(i+1, combination[i])
+			}
+		}
+	}
+	try(0, d.Position())
+	return best
+}
@@ -0,0 +1,198 @@
+package dial
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// Kind says what an instruction does to the dial.
+type Kind int
+
+const (
+	// Rotate turns the dial N clicks, right for positive N, left for negative.
+	Rotate Kind = iota
+	// Set puts the pointer straight on position N, wrapped around the dial,
+	// without turning it, so it neither passes nor lands on anything.
+	Set
+)
+
+// Instruction is one step of a day 01 program after repeats are expanded.
+type Instruction struct {
+	Kind Kind
+	N    int
+	Line int    // line of the input it came from
+	Dial string // label of the dial an odometer instruction turns, empty otherwise
+}
+
+func (in Instruction) String() string {
+	var s string
+	switch {
+	case in.Kind == Set:
+		s = fmt.Sprintf("S%d", in.N)
+	case in.N < 0:
+		s = fmt.Sprintf("L%d", -in.N)
+	default:
+		s = fmt.Sprintf("R%d", in.N)
+	}
+	if in.Dial != "" {
+		return in.Dial + ":" + s
+	}
+	return s
+}
+
+// Apply carries out one instruction, returning the new position and the
+// counts for each target as Rotate does.
+func (d *Dial) Apply(in Instruction) (int, []Counts) {
+	if in.Kind == Set {
+		d.position = Move(in.N, 0, d.size)
+		return d.position, make([]Counts, len(d.targets))
+	}
+	return d.Rotate(in.N)
+}
+
+// block is a parsed instruction or repeat block waiting to be expanded.
+type block struct {
+	in    Instruction
+	times int     // for a repeat, how many times to run body
+	body  []block // nil for a plain instruction
+}
+
+// Expand parses a program from scanner and calls fn with each instruction in
+// order, repeats expanded. The grammar is
+//
+//	R10 L5       rotate right or left
+//	S37          set the pointer to 37
+//	3x(R10 L5)   run the body three times; blocks nest and may span lines
+//	# comment    ignored to the end of the line, as are blank lines
+//
+// Instructions are separated by whitespace and handed on as soon as they are
+// complete, so only open repeat blocks are held in memory.
+func Expand(scanner *bufio.Scanner, fn func(Instruction) error) error {
+	return expandWith(scanner, parseInstruction, fn)
+}
+
+// expandWith is Expand with the instructions themselves parsed by
+// parseToken.
+func expandWith(scanner *bufio.Scanner, parseToken func(lineNum int, token string) (Instruction, error), fn func(Instruction) error) error {
+	var stack []*block // open repeat blocks, innermost last
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		for len(line) > 0 {
+			var token string
+			token, line = nextToken(line)
+			if token == "" {
+				continue
+			}
+
+			var b *block
+			switch {
+			case token == "(":
+				return parse.Errorf(lineNum, token, "parenthesis without a repeat count, expected like 3x(R10 L5)")
+			case token == ")":
+				if len(stack) == 0 {
+					return parse.Errorf(lineNum, token, "unmatched closing parenthesis")
+				}
+				b, stack = stack[len(stack)-1], stack[:len(stack)-1]
+				if len(b.body) == 0 {
+					// Repeating nothing does nothing, however many times
+					continue
+				}
+			case strings.HasSuffix(token, "("):
+				times, err := parse.Int(lineNum, strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(token, "("), "x")))
+				if err != nil {
+					return err
+				}
+				if times < 0 {
+					return parse.Errorf(lineNum, token, "negative repeat count")
+				}
+				stack = append(stack, &block{in: Instruction{Line: lineNum}, times: times, body: []block{}})
+				continue
+			default:
+				in, err := parseToken(lineNum, token)
+				if err != nil {
+					return err
+				}
+				b = &block{in: in}
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.body = append(parent.body, *b)
+				continue
+			}
+			if err := b.expand(fn); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	if len(stack) > 0 {
+		open := stack[len(stack)-1]
+		return parse.Errorf(open.in.Line, fmt.Sprintf("%dx(", open.times), "repeat block is never closed")
+	}
+	return nil
+}
+
+func (b *block) expand(fn func(Instruction) error) error {
+	if b.body == nil {
+		return fn(b.in)
+	}
+	for range b.times {
+		for i := range b.body {
+			if err := b.body[i].expand(fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// nextToken splits the first token off line: an instruction, the "3x(" that
+// opens a repeat, or ")".
+func nextToken(line string) (string, string) {
+	line = strings.TrimLeft(line, " \t\r")
+	if line == "" {
+		return "", ""
+	}
+	if line[0] == '(' || line[0] == ')' {
+		return line[:1], line[1:]
+	}
+
+	end := strings.IndexAny(line, " \t\r()")
+	if end == -1 {
+		return line, ""
+	}
+
+	// Let "3x (" through as one token with the parenthesis
+	token, rest := line[:end], line[end:]
+	if trimmed := strings.TrimLeft(rest, " \t\r"); strings.HasPrefix(trimmed, "(") && strings.HasSuffix(token, "x") {
+		return token + "(", trimmed[1:]
+	}
+	return token, rest
+}
+
+// parseInstruction parses a single instruction token.
+func parseInstruction(lineNum int, token string) (Instruction, error) {
+	if pos, ok := strings.CutPrefix(token, "S"); ok {
+		n, err := parse.Int(lineNum, pos)
+		if err != nil {
+			return Instruction{}, err
+		}
+		if n < 0 {
+			return Instruction{}, parse.Errorf(lineNum, token, "negative position")
+		}
+		return Instruction{Kind: Set, N: n, Line: lineNum}, nil
+	}
+
+	n, err := ParseRotation(lineNum, token)
+	if err != nil {
+		return Instruction{}, err
+	}
+	return Instruction{Kind: Rotate, N: n, Line: lineNum}, nil
+}
@@ -0,0 +1,316 @@
+package dial
+
+import (
+	"bufio"
+	"sync"
+)
+
+// Summary is the effect of a run of rotations on a dial, for every possible
+// start position at once. Summaries of consecutive runs combine with Then, so
+// a long instruction list can be split into chunks that are summarized in
+// parallel and stitched back together.
+//
+// Where the run ends is the start plus a fixed offset, or a fixed position
+// once it contains a Set. How often it meets a target depends on the start
+// only through which positions it turns around at, so each count is a step
+// function of the start with at most one step per dial position.
+type Summary struct {
+	size    int
+	targets []int
+	offset  int    // where the run ends relative to the start, or absolutely if fixed
+	fixed   bool   // a Set made everything after it independent of the start
+	clicks  []step // per target: clicks that pointed at it
+	stops   []step // per target: rotations that left the pointer on it
+}
+
+// Totals are what a summarized run did to one target from a given start.
+type Totals struct {
+	Clicks int // clicks that pointed at the target, as day 01 part 2 counts
+	Stops  int // rotations, including empty ones, that ended on the target, as part 1 counts
+}
+
+// denseLimit is the largest dial whose step functions are kept as a slice
+// indexed by position rather than a map.
+const denseLimit = 1 << 12
+
+// step is a step function of the start position p in [0, size): base plus
+// every delta whose position is at most p.
+type step struct {
+	base   int
+	dense  []int       // deltas by position, for small dials
+	sparse map[int]int // deltas by position, for large ones
+}
+
+func newStep(size int) step {
+	if size <= denseLimit {
+		return step{dense: make([]int, size)}
+	}
+	return step{sparse: make(map[int]int)}
+}
+
+// add adds v for every start at or after b.
+func (s *step) add(b, v, size int) {
+	switch {
+	case b <= 0:
+		s.base += v
+	case b >= size:
+	case s.dense != nil:
+		s.dense[b] += v
+	default:
+		s.sparse[b] += v
+		if s.sparse[b] == 0 {
+			delete(s.sparse, b)
+		}
+	}
+}
+
+// each calls fn for every nonzero delta.
+func (s step) each(fn func(b, d int)) {
+	for b, d := range s.dense {
+		if d != 0 {
+			fn(b, d)
+		}
+	}
+	for b, d := range s.sparse {
+		fn(b, d)
+	}
+}
+
+// addFloor adds sign * floor((p + c) / size) as a function of the start p,
+// or just for p = 0 when fixed.
+func (s *step) addFloor(c, sign, size int, fixed bool) {
+	q, r := c/size, c%size
+	if r < 0 {
+		q, r = q-1, r+size
+	}
+	s.base += sign * q
+	if r != 0 && !fixed {
+		s.add(size-r, sign, size) // p + r reaches the next multiple once p >= size - r
+	}
+}
+
+func (s step) at(p int) int {
+	v := s.base
+	s.each(func(b, d int) {
+		if b <= p {
+			v += d
+		}
+	})
+	return v
+}
+
+// plus returns s(p) + v.
+func (s step) plus(v, size int) step {
+	out := newStep(size)
+	out.base = s.base + v
+	s.each(func(b, d int) {
+		out.add(b, d, size)
+	})
+	return out
+}
+
+// plusShifted returns s(p) + t((p + o) mod size).
+func (s step) plusShifted(t step, o, size int) step {
+	out := newStep(size)
+	out.base = s.base + t.base
+	s.each(func(b, d int) {
+		out.add(b, d, size)
+	})
+
+	t.each(func(b, d int) {
+		if o == 0 {
+			out.add(b, d, size)
+			return
+		}
+
+		// (p + o) mod size >= b exactly when p is in [b - o, size - o),
+		// taken mod size, which may wrap past the end of the dial
+		from, to := b-o, size-o
+		if from < 0 {
+			from += size
+			out.base += d
+		}
+		out.add(from, d, size)
+		out.add(to, -d, size)
+	})
+
+	return out
+}
+
+// Summarize summarizes a run of instructions on a dial of size, counting
+// visits to targets.
+func Summarize(size int, targets []int, program []Instruction) *Summary {
+	s := &Summary{
+		size:    size,
+		targets: targets,
+		clicks:  make([]step, len(targets)),
+		stops:   make([]step, len(targets)),
+	}
+	for i := range targets {
+		s.clicks[i] = newStep(size)
+		s.stops[i] = newStep(size)
+	}
+
+	// Positions are written as p + offset for the unknown start p. Counting
+	// multiples of size between two of them gives floor terms in p. After a
+	// Set, positions are known outright and p drops out.
+	for _, in := range program {
+		if in.Kind == Set {
+			s.offset, s.fixed = Move(in.N, 0, size), true
+			continue
+		}
+
+		n := in.N
+		a, b := n/size, n%size
+		if n < 0 {
+			a, b = -a, -b
+		}
+
+		var next int
+		if n >= 0 {
+			next = s.offset + b
+		} else {
+			next = s.offset - b
+		}
+
+		for i, t := range targets {
+			c := &s.clicks[i]
+			switch {
+			case n > 0: // Clicks land on p+offset+1 ... p+offset+n
+				c.base += a
+				c.addFloor(s.offset+b-t, 1, size, s.fixed)
+				c.addFloor(s.offset-t, -1, size, s.fixed)
+			case n < 0: // Clicks land on p+offset-1 ... p+offset-n
+				c.base += a
+				c.addFloor(s.offset-1-t, 1, size, s.fixed)
+				c.addFloor(s.offset-b-1-t, -1, size, s.fixed)
+			}
+
+			st := &s.stops[i]
+			st.addFloor(next-t, 1, size, s.fixed)
+			st.addFloor(next-1-t, -1, size, s.fixed)
+		}
+
+		s.offset = Move(next, 0, size)
+	}
+
+	return s
+}
+
+// Then returns the summary of s's run followed by next's. Both must be for
+// the same dial and targets.
+func (s *Summary) Then(next *Summary) *Summary {
+	out := &Summary{
+		size:    s.size,
+		targets: s.targets,
+		offset:  Move(s.offset, next.offset, s.size),
+		fixed:   s.fixed || next.fixed,
+		clicks:  make([]step, len(s.targets)),
+		stops:   make([]step, len(s.targets)),
+	}
+	if next.fixed {
+		out.offset = next.offset
+	}
+
+	for i := range s.targets {
+		if s.fixed {
+			// next starts from a known position, so its counts are constants
+			out.clicks[i] = s.clicks[i].plus(next.clicks[i].at(s.offset), s.size)
+			out.stops[i] = s.stops[i].plus(next.stops[i].at(s.offset), s.size)
+			continue
+		}
+		out.clicks[i] = s.clicks[i].plusShifted(next.clicks[i], s.offset, s.size)
+		out.stops[i] = s.stops[i].plusShifted(next.stops[i], s.offset, s.size)
+	}
+	return out
+}
+
+// Apply runs the summarized instructions from start, returning where the
+// pointer ends up and the totals for each target.
+func (s *Summary) Apply(start int) (int, []Totals) {
+	totals := make([]Totals, len(s.targets))
+	for i := range s.targets {
+		totals[i] = Totals{Clicks: s.clicks[i].at(start), Stops: s.stops[i].at(start)}
+	}
+	if s.fixed {
+		return s.offset, totals
+	}
+	return Move(start, s.offset, s.size), totals
+}
+
+// SummarizeChunks summarizes each chunk of instructions from chunks on up to
+// workers goroutines and combines the results in the order the chunks
+// arrived, giving the same answer as running them one after another.
+func SummarizeChunks(size int, targets []int, chunks <-chan []Instruction, workers int) *Summary {
+	type job struct {
+		i       int
+		program []Instruction
+	}
+
+	var mu sync.Mutex
+	var summaries []*Summary
+	jobs := make(chan job)
+	var wg sync.WaitGroup
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				sum := Summarize(size, targets, j.program)
+				mu.Lock()
+				summaries[j.i] = sum
+				mu.Unlock()
+			}
+		}()
+	}
+
+	i := 0
+	for program := range chunks {
+		mu.Lock()
+		summaries = append(summaries, nil)
+		mu.Unlock()
+		jobs <- job{i, program}
+		i++
+	}
+	close(jobs)
+	wg.Wait()
+
+	total := Summarize(size, targets, nil)
+	for _, sum := range summaries {
+		total = total.Then(sum)
+	}
+	return total
+}
+
+// SummarizeScanner parses the program read by scanner into chunks of
+// chunkSize instructions and summarizes them on up to workers goroutines
+// while the rest is still being read.
+func SummarizeScanner(scanner *bufio.Scanner, size int, targets []int, workers, chunkSize int) (*Summary, error) {
+	chunkSize = max(chunkSize, 1)
+	chunks := make(chan []Instruction, max(workers, 1))
+	result := make(chan *Summary)
+	go func() {
+		result <- SummarizeChunks(size, targets, chunks, workers)
+	}()
+
+	chunk := make([]Instruction, 0, chunkSize)
+	err := Expand(scanner, func(in Instruction) error {
+		chunk = append(chunk, in)
+		if len(chunk) == chunkSize {
+			chunks <- chunk
+			chunk = make([]Instruction, 0, chunkSize)
+		}
+		return nil
+	})
+	if err == nil && len(chunk) > 0 {
+		chunks <- chunk
+	}
+	close(chunks)
+
+	// Wait for the workers even after an error, so none is left behind
+	sum := <-result
+	if err != nil {
+		return nil, err
+	}
+	return sum, nil
+}
@@ -0,0 +1,102 @@
+// Package cli is the command line shared by both parts of day 01, which
+// differ only in what they count as a visit to the target.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/dfryer1193/AoC-2025/01/dial"
+	"github.com/dfryer1193/AoC-2025/internal/anim"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+// Main runs the given part of day 01. Part 1 counts the rotations that leave
+// the pointer on the target, even rotations of zero; part 2 counts every
+// click that points at it.
+func Main(part int) {
+	size := flag.Int("size", 100, "number of positions on the dial")
+	start := flag.Int("start", 50, "position the dial starts at")
+	target := flag.Int("target", 0, "position whose visits are counted")
+	animOpts := anim.RegisterFlags()
+	auditOpts := dial.RegisterAuditFlags()
+	budget := stream.RegisterFlags()
+	workers := flag.Int("parallel", 0, "summarize chunks of instructions on this many goroutines (0 runs them in order)")
+	chunkSize := flag.Int("chunk", 1<<16, "instructions per chunk with -parallel")
+	flag.Parse()
+
+	count := func(c dial.Counts) int { return c.Stops }
+	total := func(t dial.Totals) int { return t.Stops }
+	if part == 2 {
+		count = dial.Counts.Clicks
+		total = func(t dial.Totals) int { return t.Clicks }
+	}
+
+	filename := "input.txt"
+	if flag.NArg() > 0 {
+		filename = flag.Arg(0)
+	}
+
+	d, err := dial.New(*size, *start, *target)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := budget.Open(filename, stream.Lines)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	rec, err := animOpts.Open(fmt.Sprintf("Day 1 part %d", part))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rec.Close()
+
+	audit, err := auditOpts.Open(*size)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scanner := budget.Scanner(f)
+
+	if *workers > 0 {
+		if rec.Enabled() || audit.Enabled() {
+			log.Fatal("Animations and audits need the instructions in order; drop -parallel")
+		}
+		sum, err := dial.SummarizeScanner(scanner, d.Size(), d.Targets(), *workers, *chunkSize)
+		if err != nil {
+			log.Fatal(err)
+		}
+		_, totals := sum.Apply(d.Position())
+		fmt.Println(total(totals[0]))
+		return
+	}
+
+	zeroCount := 0
+
+	err = dial.Expand(scanner, func(in dial.Instruction) error {
+		start := d.Position()
+		position, counts := d.Apply(in)
+		zeroCount += count(counts[0])
+		if err := audit.Record(in, start, position, counts[0]); err != nil {
+			return err
+		}
+
+		if rec.Enabled() {
+			caption := fmt.Sprintf("%s -> %d  zeros: %d", in, position, zeroCount)
+			return rec.Frame(d.Frame(), caption)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := audit.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(zeroCount)
+}
@@ -0,0 +1,9 @@
+// Command part2 sums the invalid IDs in the day 02 ranges, which by default
+// are those made of a block repeated at least twice. See package cli for the flags.
+package main
+
+import "github.com/dfryer1193/AoC-2025/02/internal/cli"
+
+func main() {
+	cli.Main("atleast:2")
+}
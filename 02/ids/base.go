@@ -0,0 +1,38 @@
+package ids
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Base is the radix IDs are written in, from 2 to 36. Digits past 9 are the
+// letters a to z, in either case on input and lower case on output.
+type Base int
+
+// Decimal is the base of the puzzle's IDs.
+const Decimal Base = 10
+
+// NewBase checks that n is a usable base.
+func NewBase(n int) (Base, error) {
+	if n < 2 || n > 36 {
+		return 0, fmt.Errorf("base %d is not between 2 and 36", n)
+	}
+	return Base(n), nil
+}
+
+// Format returns the digits of n in base b.
+func (b Base) Format(n *big.Int) string {
+	return n.Text(int(b))
+}
+
+// digits returns the number of digits in n, counting 0 as one digit.
+func (b Base) digits(n *big.Int) int {
+	if n.Sign() == 0 {
+		return 1
+	}
+	return len(b.Format(n))
+}
+
+func (b Base) pow(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(int64(b)), big.NewInt(int64(n)), nil)
+}
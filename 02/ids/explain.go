@@ -0,0 +1,105 @@
+package ids
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"math/big"
+	"strconv"
+
+	"github.com/dfryer1193/AoC-2025/internal/report"
+)
+
+// Explainer lists every invalid ID it finds along with the range it came
+// from, its minimal repeating block and how many times the block repeats,
+// and a subtotal for each range, as a report (CSV, or JSON for .json). IDs
+// and sums are written as strings in JSON since they can outgrow 64 bits.
+//
+// Rows are written as they are found, so explaining a long input costs no
+// memory, though a range holding billions of invalid IDs makes for a very
+// large file.
+type Explainer struct {
+	out  *report.Writer
+	base Base
+}
+
+// explained is one invalid ID in JSON output.
+type explained struct {
+	ID          string `json:"id"`
+	Block       string `json:"block"`
+	Repetitions int    `json:"repetitions"`
+}
+
+// CreateExplainer starts an explanation at path ("-" for stdout), writing
+// IDs and blocks in base b.
+func CreateExplainer(path string, b Base) (*Explainer, error) {
+	out, err := report.Create(path, "range", "kind", "id", "block", "repetitions", "count", "sum")
+	if err != nil {
+		return nil, err
+	}
+	return &Explainer{out: out, base: b}, nil
+}
+
+// Range walks the IDs in [lo, hi] that rule matches, writing each one and
+// then the range's subtotal, which it returns. name is how the range
+// appeared in the input.
+func (e *Explainer) Range(name string, lo, hi *big.Int, rule Rule) (Total, error) {
+	// In JSON each range is one record, with its IDs streamed inside it
+	total := newTotal()
+	var w *bufio.Writer // nil when writing CSV
+	if e.out.JSON() {
+		w = e.out.Begin()
+		w.WriteString("{\"range\": ")
+		writeJSON(w, name)
+		w.WriteString(", \"ids\": [")
+	}
+
+	var err error
+	e.base.Walk(lo, hi, rule, func(n *big.Int) bool {
+		digits := e.base.Format(n)
+		block := MinimalBlock(digits)
+		if w == nil {
+			err = e.out.Row(name, "id", digits, digits[:block], strconv.Itoa(len(digits)/block), "", "")
+		} else {
+			if total.Count.Sign() > 0 {
+				w.WriteString(",")
+			}
+			w.WriteString("\n    ")
+			err = writeJSON(w, explained{ID: digits, Block: digits[:block], Repetitions: len(digits) / block})
+		}
+
+		total.Count.Add(total.Count, one)
+		total.Sum.Add(total.Sum, n)
+		return err == nil
+	})
+	if err != nil {
+		return total, err
+	}
+
+	if w == nil {
+		return total, e.out.Row(name, "subtotal", "", "", "", total.Count.String(), total.Sum.String())
+	}
+	if total.Count.Sign() > 0 {
+		w.WriteString("\n  ")
+	}
+	w.WriteString("], \"count\": ")
+	writeJSON(w, total.Count.String())
+	w.WriteString(", \"sum\": ")
+	writeJSON(w, total.Sum.String())
+	_, err = w.WriteString("}")
+	return total, err
+}
+
+// Close finishes the explanation.
+func (e *Explainer) Close() error {
+	return e.out.Close()
+}
+
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,91 @@
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// maxDigits bounds how long an ID the order queries look for before deciding
+// a rule has no more matches.
+const maxDigits = 4096
+
+// ErrNoMatch is returned by the order queries when there is no such ID.
+var ErrNoMatch = errors.New("no such invalid ID")
+
+// Count returns how many IDs in [lo, hi] rule matches. Like the other order
+// queries it refuses rules that can only be counted by scanning, which Sum
+// will do if asked.
+func (b Base) Count(lo, hi *big.Int, rule Rule) (*big.Int, error) {
+	if _, err := countable(rule); err != nil {
+		return nil, err
+	}
+	return b.Sum(lo, hi, rule).Count, nil
+}
+
+// Kth returns the k-th smallest ID that rule matches, counting from 1. It
+// works from counts alone, so rule must be one that can be counted in closed
+// form, exactly:K or atleast:K: the matching length is found by totalling
+// whole lengths, then the ID within it by bisection.
+func (b Base) Kth(k *big.Int, rule Rule) (*big.Int, error) {
+	t, err := countable(rule)
+	if err != nil {
+		return nil, err
+	}
+	if k.Sign() <= 0 {
+		return nil, ErrNoMatch
+	}
+
+	remaining := new(big.Int).Set(k)
+	for length := 1; length <= maxDigits; length++ {
+		from := b.pow(length - 1)
+		to := new(big.Int).Sub(b.pow(length), one)
+		count := t.total(b, from, to, length).Count
+		if count.Cmp(remaining) < 0 {
+			remaining.Sub(remaining, count)
+			continue
+		}
+
+		// The smallest m with remaining matches in [from, m]
+		lo, hi := from, to
+		for lo.Cmp(hi) < 0 {
+			mid := new(big.Int).Add(lo, hi)
+			mid.Rsh(mid, 1)
+			if t.total(b, from, mid, length).Count.Cmp(remaining) >= 0 {
+				hi = mid
+			} else {
+				lo = mid.Add(mid, one)
+			}
+		}
+		return lo, nil
+	}
+	return nil, ErrNoMatch
+}
+
+// Next returns the smallest ID at or after n that rule matches. Like Kth, it
+// needs a rule that can be counted in closed form.
+func (b Base) Next(n *big.Int, rule Rule) (*big.Int, error) {
+	k, err := b.Count(new(big.Int), new(big.Int).Sub(n, one), rule)
+	if err != nil {
+		return nil, err
+	}
+	return b.Kth(k.Add(k, one), rule)
+}
+
+// Previous returns the largest ID before n that rule matches, or ErrNoMatch
+// if there isn't one.
+func (b Base) Previous(n *big.Int, rule Rule) (*big.Int, error) {
+	k, err := b.Count(new(big.Int), new(big.Int).Sub(n, one), rule)
+	if err != nil {
+		return nil, err
+	}
+	return b.Kth(k, rule)
+}
+
+func countable(rule Rule) (totaler, error) {
+	t, ok := rule.(totaler)
+	if !ok {
+		return nil, fmt.Errorf("%s can't be counted without scanning; order queries need exactly:K or atleast:K", rule)
+	}
+	return t, nil
+}
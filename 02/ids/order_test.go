@@ -0,0 +1,158 @@
+package ids
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"testing"
+)
+
+// matches lists every ID in [0, limit) that rule matches, by testing each.
+func matches(b Base, rule Rule, limit int64) []int64 {
+	var found []int64
+	for n := int64(0); n < limit; n++ {
+		if rule.Match(b.Format(big.NewInt(n))) {
+			found = append(found, n)
+		}
+	}
+	return found
+}
+
+func TestOrderMatchesBruteForce(t *testing.T) {
+	rules := []Rule{Exactly(2), AtLeast(2), Exactly(3)}
+	bases := []Base{2, 10, 16}
+
+	for _, rule := range rules {
+		for _, b := range bases {
+			t.Run(fmt.Sprintf("%s base %d", rule, b), func(t *testing.T) {
+				limit := min(b.pow(6).Int64(), 1<<18)
+				found := matches(b, rule, limit)
+				last := found[len(found)-1]
+
+				for i, want := range found {
+					got, err := b.Kth(big.NewInt(int64(i+1)), rule)
+					if err != nil || got.Int64() != want {
+						t.Fatalf("Kth(%d) = %v, %v, want %d", i+1, got, err, want)
+					}
+				}
+
+				// Every query around each match, so bounds that are matches
+				// themselves are covered along with those that aren't
+				for i, m := range found {
+					for _, n := range []int64{m - 1, m, m + 1} {
+						if n < 0 || n > last {
+							continue
+						}
+						checkNext(t, b, rule, found, n)
+						checkPrevious(t, b, rule, found, n)
+					}
+					if i > 0 {
+						checkCount(t, b, rule, found, found[i-1], m)
+					}
+				}
+
+				r := rand.New(rand.NewPCG(2025, uint64(b)))
+				for range 500 {
+					lo := r.Int64N(limit)
+					hi := lo + r.Int64N(limit-lo)
+					checkCount(t, b, rule, found, lo, hi)
+				}
+			})
+		}
+	}
+}
+
+func checkNext(t *testing.T, b Base, rule Rule, found []int64, n int64) {
+	t.Helper()
+	var want int64
+	for _, m := range found {
+		if m >= n {
+			want = m
+			break
+		}
+	}
+	got, err := b.Next(big.NewInt(n), rule)
+	if err != nil || got.Int64() != want {
+		t.Fatalf("Next(%d) = %v, %v, want %d", n, got, err, want)
+	}
+}
+
+func checkPrevious(t *testing.T, b Base, rule Rule, found []int64, n int64) {
+	t.Helper()
+	want := int64(-1)
+	for _, m := range found {
+		if m < n {
+			want = m
+		}
+	}
+	got, err := b.Previous(big.NewInt(n), rule)
+	if want < 0 {
+		if !errors.Is(err, ErrNoMatch) {
+			t.Fatalf("Previous(%d) = %v, %v, want ErrNoMatch", n, got, err)
+		}
+		return
+	}
+	if err != nil || got.Int64() != want {
+		t.Fatalf("Previous(%d) = %v, %v, want %d", n, got, err, want)
+	}
+}
+
+func checkCount(t *testing.T, b Base, rule Rule, found []int64, lo, hi int64) {
+	t.Helper()
+	want := int64(0)
+	for _, m := range found {
+		if lo <= m && m <= hi {
+			want++
+		}
+	}
+	got, err := b.Count(big.NewInt(lo), big.NewInt(hi), rule)
+	if err != nil || got.Int64() != want {
+		t.Fatalf("Count(%d, %d) = %v, %v, want %d", lo, hi, got, err, want)
+	}
+}
+
+func TestOrderEdges(t *testing.T) {
+	rule := Exactly(2)
+
+	t.Run("k = 0", func(t *testing.T) {
+		if got, err := Decimal.Kth(new(big.Int), rule); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Kth(0) = %v, %v, want ErrNoMatch", got, err)
+		}
+	})
+
+	t.Run("k past the end", func(t *testing.T) {
+		// Nothing is looked for past maxDigits digits
+		k, err := Decimal.Count(new(big.Int), new(big.Int).Sub(Decimal.pow(maxDigits), one), rule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, err := Decimal.Kth(k, rule); err != nil || Decimal.digits(got) != maxDigits {
+			t.Errorf("Kth(%s) = %v, %v, want the last %d-digit match", k, got, err, maxDigits)
+		}
+		if got, err := Decimal.Kth(k.Add(k, one), rule); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Kth(past the end) = %v, %v, want ErrNoMatch", got, err)
+		}
+	})
+
+	t.Run("n = 0", func(t *testing.T) {
+		if got, err := Decimal.Next(new(big.Int), rule); err != nil || got.Int64() != 11 {
+			t.Errorf("Next(0) = %v, %v, want 11", got, err)
+		}
+		if got, err := Decimal.Previous(new(big.Int), rule); !errors.Is(err, ErrNoMatch) {
+			t.Errorf("Previous(0) = %v, %v, want ErrNoMatch", got, err)
+		}
+		if got, err := Decimal.Count(new(big.Int), new(big.Int), rule); err != nil || got.Sign() != 0 {
+			t.Errorf("Count(0, 0) = %v, %v, want 0", got, err)
+		}
+	})
+
+	t.Run("uncountable rule", func(t *testing.T) {
+		if _, err := Decimal.Kth(one, Palindrome()); err == nil {
+			t.Error("Kth with palindrome succeeded, want an error")
+		}
+		if _, err := Decimal.Next(one, Palindrome()); err == nil {
+			t.Error("Next with palindrome succeeded, want an error")
+		}
+	})
+}
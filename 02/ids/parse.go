@@ -0,0 +1,38 @@
+package ids
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// ParseRange parses a range like "11-22" written in base b. IDs may be any
+// size, but the end may not come before the start.
+func (b Base) ParseRange(lineNum int, r string) (*big.Int, *big.Int, error) {
+	from, to, ok := strings.Cut(r, "-")
+	if !ok {
+		return nil, nil, parse.Errorf(lineNum, r, "invalid range format")
+	}
+
+	lo, err := b.parseID(lineNum, from)
+	if err != nil {
+		return nil, nil, err
+	}
+	hi, err := b.parseID(lineNum, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	if hi.Cmp(lo) < 0 {
+		return nil, nil, parse.Errorf(lineNum, r, "range end is before its start")
+	}
+	return lo, hi, nil
+}
+
+func (b Base) parseID(lineNum int, text string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(text, int(b))
+	if !ok || n.Sign() < 0 {
+		return nil, parse.Errorf(lineNum, text, "bad number")
+	}
+	return n, nil
+}
@@ -0,0 +1,76 @@
+package ids
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Range is an inclusive range of IDs and how it was written in the input.
+type Range struct {
+	Lo, Hi *big.Int
+	Text   string
+}
+
+// Overlap says what to do about input ranges that share IDs.
+type Overlap string
+
+const (
+	// MergeOverlaps combines overlapping ranges, so every ID counts once.
+	MergeOverlaps Overlap = "merge"
+	// CountOverlaps sums each range on its own, counting shared IDs once per
+	// range that holds them.
+	CountOverlaps Overlap = "count"
+	// RejectOverlaps treats overlapping ranges as an input error.
+	RejectOverlaps Overlap = "reject"
+)
+
+// ParseOverlap checks an overlap policy name.
+func ParseOverlap(s string) (Overlap, error) {
+	switch o := Overlap(s); o {
+	case MergeOverlaps, CountOverlaps, RejectOverlaps:
+		return o, nil
+	}
+	return "", fmt.Errorf("unknown overlap policy %q, expected merge, count or reject", s)
+}
+
+// Merge sorts ranges and combines any that overlap or touch. Merged ranges
+// are written lo-hi in base b.
+func (b Base) Merge(ranges []Range) []Range {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range) int { return a.Lo.Cmp(b.Lo) })
+
+	var merged []Range
+	for _, r := range sorted {
+		if n := len(merged); n > 0 {
+			last := &merged[n-1]
+			if new(big.Int).Add(last.Hi, one).Cmp(r.Lo) >= 0 {
+				if r.Hi.Cmp(last.Hi) > 0 {
+					last.Hi = r.Hi
+				}
+				last.Text = b.Format(last.Lo) + "-" + b.Format(last.Hi)
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// FindOverlap returns a pair of ranges that share an ID, if any.
+func FindOverlap(ranges []Range) (Range, Range, bool) {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range) int { return a.Lo.Cmp(b.Lo) })
+
+	for i := 1; i < len(sorted); i++ {
+		// Ranges sorted by start overlap an earlier one only if they overlap
+		// the one reaching furthest, which is checked as we go
+		if sorted[i].Lo.Cmp(sorted[i-1].Hi) <= 0 {
+			return sorted[i-1], sorted[i], true
+		}
+		if sorted[i].Hi.Cmp(sorted[i-1].Hi) < 0 {
+			sorted[i] = sorted[i-1]
+		}
+	}
+	return Range{}, Range{}, false
+}
@@ -0,0 +1,239 @@
+// Package ids finds the invalid product IDs of day 02: numbers whose digits
+// are a single block repeated, like 6464 or 123123123. Digits are in any base
+// from 2 to 36, so hex codes like 5a5a are repeated blocks too.
+//
+// Rather than testing every number in a range, it counts and sums the
+// repeated-block numbers directly. A number of L digits made of a p-digit
+// block B repeated L/p times is B times 100..0100..01 in its base, so for
+// each length and period the matching numbers in a range are an interval of
+// blocks. Numbers with several periods (1111 repeats both 1 and 11) are
+// counted once by working with minimal periods.
+package ids
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"strings"
+)
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+// Total is the count and sum of a set of IDs.
+type Total struct {
+	Count *big.Int
+	Sum   *big.Int
+}
+
+func newTotal() Total {
+	return Total{Count: new(big.Int), Sum: new(big.Int)}
+}
+
+func (t Total) add(u Total) {
+	t.Count.Add(t.Count, u.Count)
+	t.Sum.Add(t.Sum, u.Sum)
+}
+
+func (t Total) sub(u Total) {
+	t.Count.Sub(t.Count, u.Count)
+	t.Sum.Sub(t.Sum, u.Sum)
+}
+
+// Exactly matches IDs made of a block repeated exactly k times, like 6464
+// for k = 2. Day 02 part 1 is Exactly(2).
+func Exactly(k int) Rule {
+	return exactly(k)
+}
+
+type exactly int
+
+func (r exactly) String() string {
+	return fmt.Sprintf("exactly:%d", int(r))
+}
+
+func (r exactly) Match(digits string) bool {
+	k := int(r)
+	return k >= 1 && len(digits)%k == 0 && strings.Repeat(digits[:len(digits)/k], k) == digits
+}
+
+func (r exactly) total(b Base, from, to *big.Int, length int) Total {
+	k := int(r)
+	if k < 1 || length%k != 0 {
+		return newTotal()
+	}
+	return b.periodic(from, to, length, length/k)
+}
+
+func (r exactly) generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool {
+	k := int(r)
+	if k < 1 || length%k != 0 {
+		return true
+	}
+	return b.eachPeriodic(from, to, length, length/k, fn)
+}
+
+// AtLeast matches IDs made of a block repeated k or more times. Day 02 part 2
+// is AtLeast(2).
+func AtLeast(k int) Rule {
+	return atLeast(k)
+}
+
+type atLeast int
+
+func (r atLeast) String() string {
+	return fmt.Sprintf("atleast:%d", int(r))
+}
+
+func (r atLeast) Match(digits string) bool {
+	return len(digits)/MinimalBlock(digits) >= int(r)
+}
+
+func (r atLeast) total(b Base, from, to *big.Int, length int) Total {
+	if int(r) <= 1 { // Everything is at least its whole self once
+		return b.periodic(from, to, length, length)
+	}
+
+	total := newTotal()
+	for d, t := range b.minimalPeriods(from, to, length) {
+		if length/d >= int(r) {
+			total.add(t)
+		}
+	}
+	return total
+}
+
+func (r atLeast) generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool {
+	if int(r) <= 1 {
+		return b.eachPeriodic(from, to, length, length, fn)
+	}
+
+	// Each period lists its numbers in order; each ID is kept only by its
+	// minimal period, and the periods are merged
+	var runs []iter.Seq[*big.Int]
+	for d := 1; d < length; d++ {
+		if length%d != 0 || length/d < int(r) {
+			continue
+		}
+		runs = append(runs, func(yield func(*big.Int) bool) {
+			b.eachPeriodic(from, to, length, d, func(n *big.Int) bool {
+				return MinimalBlock(b.Format(n)) != d || yield(n)
+			})
+		})
+	}
+	return merge(runs, fn)
+}
+
+// MinimalBlock returns the length of the shortest block that digits is a
+// repetition of, which is len(digits) when it repeats nothing.
+func MinimalBlock(digits string) int {
+	for p := 1; p < len(digits); p++ {
+		if len(digits)%p == 0 && strings.Repeat(digits[:p], len(digits)/p) == digits {
+			return p
+		}
+	}
+	return len(digits)
+}
+
+// eachLength splits [lo, hi] by number of digits, calling fn with each
+// length and the part of the range with that many digits until fn returns
+// false.
+func (b Base) eachLength(lo, hi *big.Int, fn func(length int, from, to *big.Int) bool) {
+	if lo.Sign() < 0 {
+		lo = new(big.Int)
+	}
+	if hi.Cmp(lo) < 0 {
+		return
+	}
+
+	for length := b.digits(lo); length <= b.digits(hi); length++ {
+		from := maxInt(lo, b.pow(length-1))
+		to := minInt(hi, new(big.Int).Sub(b.pow(length), one))
+		if from.Cmp(to) <= 0 && !fn(length, from, to) {
+			return
+		}
+	}
+}
+
+// periodic totals the length-digit numbers in [from, to] that repeat with
+// period p, that is, are some p-digit block repeated length/p times.
+func (b Base) periodic(from, to *big.Int, length, p int) Total {
+	mult, first, last := b.blocks(from, to, length, p)
+
+	t := newTotal()
+	if first.Cmp(last) > 0 {
+		return t
+	}
+
+	// mult * (first + ... + last)
+	t.Count.Sub(last, first).Add(t.Count, one)
+	t.Sum.Add(first, last).Mul(t.Sum, t.Count).Quo(t.Sum, two).Mul(t.Sum, mult)
+	return t
+}
+
+// blocks returns the multiplier that repeats a p-digit block out to length
+// digits, and the first and last blocks whose repetitions fall in [from, to].
+func (b Base) blocks(from, to *big.Int, length, p int) (mult, first, last *big.Int) {
+	// The block is multiplied by 1 followed by length/p - 1 copies of
+	// (p-1 zeros then a 1): (base^length - 1) / (base^p - 1)
+	mult = new(big.Int).Sub(b.pow(length), one)
+	mult.Quo(mult, new(big.Int).Sub(b.pow(p), one))
+
+	first = new(big.Int).Add(from, mult)
+	first.Sub(first, one).Quo(first, mult) // Round up
+	first = maxInt(first, b.pow(p-1))
+	last = new(big.Int).Quo(to, mult)
+	last = minInt(last, new(big.Int).Sub(b.pow(p), one))
+	return mult, first, last
+}
+
+// eachPeriodic calls fn with each length-digit number in [from, to] that
+// repeats with period p, in increasing order, until fn returns false.
+func (b Base) eachPeriodic(from, to *big.Int, length, p int, fn func(*big.Int) bool) bool {
+	mult, first, last := b.blocks(from, to, length, p)
+	for block := first; block.Cmp(last) <= 0; block = new(big.Int).Add(block, one) {
+		if !fn(new(big.Int).Mul(block, mult)) {
+			return false
+		}
+	}
+	return true
+}
+
+// minimalPeriods totals the length-digit numbers in [from, to] by their
+// smallest repeating block length d < length. A number repeating with period
+// p also repeats with every multiple of its minimal period that divides
+// length, so the numbers with minimal period d are those with period d less
+// those with a smaller period dividing d.
+func (b Base) minimalPeriods(from, to *big.Int, length int) map[int]Total {
+	byPeriod := make(map[int]Total)
+	for d := 1; d < length; d++ {
+		if length%d != 0 {
+			continue
+		}
+
+		t := b.periodic(from, to, length, d)
+		for e, smaller := range byPeriod {
+			if d%e == 0 {
+				t.sub(smaller)
+			}
+		}
+		byPeriod[d] = t
+	}
+	return byPeriod
+}
+
+func maxInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) <= 0 {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,72 @@
+package ids
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestSumExample(t *testing.T) {
+	example, err := os.ReadFile("../testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rule Rule
+		want int64
+	}{
+		{Exactly(2), 1227775554},
+		{AtLeast(2), 4174379265},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule.String(), func(t *testing.T) {
+			sum := new(big.Int)
+			for _, text := range strings.Split(strings.TrimSpace(string(example)), ",") {
+				lo, hi, err := Decimal.ParseRange(1, text)
+				if err != nil {
+					t.Fatal(err)
+				}
+				sum.Add(sum, Decimal.Sum(lo, hi, tt.rule).Sum)
+			}
+			if sum.Int64() != tt.want {
+				t.Errorf("sum = %s, want %d", sum, tt.want)
+			}
+		})
+	}
+}
+
+func TestSumMatchesBruteForce(t *testing.T) {
+	rules := []Rule{Exactly(2), AtLeast(2), Exactly(3), AtLeast(3)}
+	bases := []Base{2, 3, 7, 10, 16, 36}
+
+	for _, rule := range rules {
+		for _, b := range bases {
+			t.Run(fmt.Sprintf("%s base %d", rule, b), func(t *testing.T) {
+				// Cover a few digit lengths, including where they change
+				limit := min(b.pow(6).Int64(), 1<<20)
+				r := rand.New(rand.NewPCG(2025, uint64(b)))
+				for range 300 {
+					lo := r.Int64N(limit)
+					hi := lo + r.Int64N(2000)
+					got := b.Sum(big.NewInt(lo), big.NewInt(hi), rule)
+
+					count, sum := int64(0), int64(0)
+					for n := lo; n <= hi; n++ {
+						if rule.Match(b.Format(big.NewInt(n))) {
+							count++
+							sum += n
+						}
+					}
+					if got.Count.Int64() != count || got.Sum.Int64() != sum {
+						t.Fatalf("Sum(%d, %d) = %s, %s, want %d, %d", lo, hi, got.Count, got.Sum, count, sum)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,343 @@
+package ids
+
+import (
+	"fmt"
+	"iter"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rule decides whether an ID is invalid from its digits.
+type Rule interface {
+	Match(digits string) bool
+	String() string
+}
+
+// totaler is implemented by rules whose matches in a range can be counted
+// and summed in closed form.
+type totaler interface {
+	total(b Base, from, to *big.Int, length int) Total
+}
+
+// generator is implemented by rules whose matches can be listed directly,
+// far faster than testing every number. generate calls fn with each match
+// among the numbers in [from, to] with length digits in base b, in
+// increasing order, until fn returns false, and reports whether it ran to
+// the end.
+type generator interface {
+	generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool
+}
+
+// Palindrome matches IDs that read the same backwards, like 12321.
+func Palindrome() Rule {
+	return palindrome{}
+}
+
+type palindrome struct{}
+
+func (palindrome) String() string {
+	return "palindrome"
+}
+
+func (palindrome) Match(digits string) bool {
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		if digits[i] != digits[j] {
+			return false
+		}
+	}
+	return true
+}
+
+func (palindrome) generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool {
+	// A palindrome is fixed by its first half, and grows with it
+	half := (length + 1) / 2
+	h, _ := new(big.Int).SetString(b.Format(from)[:half], int(b))
+	end := b.pow(half)
+	for ; h.Cmp(end) < 0; h = new(big.Int).Add(h, one) {
+		front := b.Format(h)
+		n, _ := new(big.Int).SetString(front+reverse(front[:length/2]), int(b))
+		if n.Cmp(from) < 0 {
+			continue
+		}
+		if n.Cmp(to) > 0 || !fn(n) {
+			return n.Cmp(to) > 0
+		}
+	}
+	return true
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+// DigitSum matches IDs whose digits add up to a value satisfying op n, where
+// op is one of = != < <= > >=. Letters count as the digits 10 to 35.
+func DigitSum(op string, n int) (Rule, error) {
+	switch op {
+	case "=", "!=", "<", "<=", ">", ">=":
+		return digitSum{op, n}, nil
+	}
+	return nil, fmt.Errorf("unknown comparison %q", op)
+}
+
+type digitSum struct {
+	op string
+	n  int
+}
+
+func (r digitSum) String() string {
+	return fmt.Sprintf("digitsum%s%d", r.op, r.n)
+}
+
+func (r digitSum) Match(digits string) bool {
+	sum := 0
+	for _, c := range digits {
+		v, _ := strconv.ParseInt(string(c), 36, 0)
+		sum += int(v)
+	}
+
+	switch r.op {
+	case "=":
+		return sum == r.n
+	case "!=":
+		return sum != r.n
+	case "<":
+		return sum < r.n
+	case "<=":
+		return sum <= r.n
+	case ">":
+		return sum > r.n
+	}
+	return sum >= r.n
+}
+
+// And matches IDs that every rule matches.
+func And(rules ...Rule) Rule {
+	return and(rules)
+}
+
+type and []Rule
+
+func (r and) String() string {
+	return join(r, " and ")
+}
+
+func (r and) Match(digits string) bool {
+	for _, rule := range r {
+		if !rule.Match(digits) {
+			return false
+		}
+	}
+	return true
+}
+
+// generate lists the matches of the first operand that can be listed and
+// filters them through the rest.
+func (r and) generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool {
+	for _, rule := range r {
+		if g, ok := rule.(generator); ok {
+			return g.generate(b, from, to, length, func(n *big.Int) bool {
+				if r.Match(b.Format(n)) {
+					return fn(n)
+				}
+				return true
+			})
+		}
+	}
+	return b.scan(from, to, r, fn)
+}
+
+// Or matches IDs that any rule matches.
+func Or(rules ...Rule) Rule {
+	return or(rules)
+}
+
+type or []Rule
+
+func (r or) String() string {
+	return "(" + join(r, " or ") + ")"
+}
+
+func (r or) Match(digits string) bool {
+	for _, rule := range r {
+		if rule.Match(digits) {
+			return true
+		}
+	}
+	return false
+}
+
+// generate lists every operand's matches when all of them can be listed,
+// merging them into one increasing run without repeats.
+func (r or) generate(b Base, from, to *big.Int, length int, fn func(*big.Int) bool) bool {
+	runs := make([]iter.Seq[*big.Int], len(r))
+	for i, rule := range r {
+		g, ok := rule.(generator)
+		if !ok {
+			return b.scan(from, to, r, fn)
+		}
+		runs[i] = func(yield func(*big.Int) bool) {
+			g.generate(b, from, to, length, yield)
+		}
+	}
+	return merge(runs, fn)
+}
+
+// Not matches IDs that rule doesn't.
+func Not(rule Rule) Rule {
+	return not{rule}
+}
+
+type not struct {
+	rule Rule
+}
+
+func (r not) String() string {
+	return "not " + r.rule.String()
+}
+
+func (r not) Match(digits string) bool {
+	return !r.rule.Match(digits)
+}
+
+func join(rules []Rule, sep string) string {
+	s := make([]string, len(rules))
+	for i, rule := range rules {
+		s[i] = rule.String()
+	}
+	return strings.Join(s, sep)
+}
+
+// ParseRule parses a rule expression such as
+//
+//	atleast:2 and not (palindrome or digitsum>20)
+//
+// Its atoms are exactly:K, atleast:K, palindrome and digitsum followed by a
+// comparison (= != < <= > >=) and a number. "not" binds tightest, then
+// "and", then "or", and parentheses group.
+func ParseRule(expr string) (Rule, error) {
+	p := &ruleParser{tokens: strings.Fields(strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr))}
+	rule, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q in rule %q", p.tokens[p.pos], expr)
+	}
+	return rule, nil
+}
+
+type ruleParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *ruleParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+func (p *ruleParser) or() (Rule, error) {
+	rules, err := p.list("or", p.and)
+	if err != nil || len(rules) == 1 {
+		return first(rules), err
+	}
+	return Or(rules...), nil
+}
+
+func (p *ruleParser) and() (Rule, error) {
+	rules, err := p.list("and", p.not)
+	if err != nil || len(rules) == 1 {
+		return first(rules), err
+	}
+	return And(rules...), nil
+}
+
+// list parses operands of next separated by op.
+func (p *ruleParser) list(op string, next func() (Rule, error)) ([]Rule, error) {
+	var rules []Rule
+	for {
+		rule, err := next()
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+		if p.peek() != op {
+			return rules, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *ruleParser) not() (Rule, error) {
+	switch p.peek() {
+	case "not":
+		p.pos++
+		rule, err := p.not()
+		if err != nil {
+			return nil, err
+		}
+		return Not(rule), nil
+	case "(":
+		p.pos++
+		rule, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return rule, nil
+	case "":
+		return nil, fmt.Errorf("rule ends early")
+	}
+
+	token := p.peek()
+	p.pos++
+	return parseAtom(token)
+}
+
+func parseAtom(token string) (Rule, error) {
+	if token == "palindrome" {
+		return Palindrome(), nil
+	}
+
+	if name, arg, ok := strings.Cut(token, ":"); ok && (name == "exactly" || name == "atleast") {
+		k, err := strconv.Atoi(arg)
+		if err != nil || k < 1 {
+			return nil, fmt.Errorf("bad repetition count in %q", token)
+		}
+		if name == "exactly" {
+			return Exactly(k), nil
+		}
+		return AtLeast(k), nil
+	}
+
+	if rest, ok := strings.CutPrefix(token, "digitsum"); ok {
+		i := strings.IndexFunc(rest, func(r rune) bool { return r >= '0' && r <= '9' || r == '-' })
+		if i > 0 {
+			n, err := strconv.Atoi(rest[i:])
+			if err == nil {
+				return DigitSum(rest[:i], n)
+			}
+		}
+		return nil, fmt.Errorf("bad digit sum rule %q, expected like digitsum<=20", token)
+	}
+
+	return nil, fmt.Errorf("unknown rule %q", token)
+}
+
+func first(rules []Rule) Rule {
+	if len(rules) == 0 {
+		return nil
+	}
+	return rules[0]
+}
@@ -0,0 +1,94 @@
+package ids
+
+import (
+	"iter"
+	"math/big"
+)
+
+// Walk calls fn with every ID in [lo, hi] that rule matches, in increasing
+// order, until fn returns false. Rules that can list their matches are asked
+// to; anything else falls back to testing each number in turn.
+func (b Base) Walk(lo, hi *big.Int, rule Rule, fn func(*big.Int) bool) {
+	b.eachLength(lo, hi, func(length int, from, to *big.Int) bool {
+		if g, ok := rule.(generator); ok {
+			return g.generate(b, from, to, length, fn)
+		}
+		return b.scan(from, to, rule, fn)
+	})
+}
+
+// Sum counts and sums the IDs in [lo, hi] that rule matches, in closed form
+// where the rule allows.
+func (b Base) Sum(lo, hi *big.Int, rule Rule) Total {
+	total := newTotal()
+	if t, ok := rule.(totaler); ok {
+		b.eachLength(lo, hi, func(length int, from, to *big.Int) bool {
+			total.add(t.total(b, from, to, length))
+			return true
+		})
+		return total
+	}
+
+	b.Walk(lo, hi, rule, func(n *big.Int) bool {
+		total.Count.Add(total.Count, one)
+		total.Sum.Add(total.Sum, n)
+		return true
+	})
+	return total
+}
+
+// scan tests every number in [from, to] against rule.
+func (b Base) scan(from, to *big.Int, rule Rule, fn func(*big.Int) bool) bool {
+	for n := new(big.Int).Set(from); n.Cmp(to) <= 0; n = new(big.Int).Add(n, one) {
+		if rule.Match(b.Format(n)) && !fn(n) {
+			return false
+		}
+	}
+	return true
+}
+
+// merge calls fn with the numbers of every run in increasing order until fn
+// returns false, listing a number found by several runs once. Each run must
+// be increasing. Only the head of each run is held, so memory doesn't grow
+// with the number of matches.
+func merge(runs []iter.Seq[*big.Int], fn func(*big.Int) bool) bool {
+	type head struct {
+		n    *big.Int
+		next func() (*big.Int, bool)
+	}
+
+	var heads []head
+	for _, run := range runs {
+		next, stop := iter.Pull(run)
+		defer stop()
+		if n, ok := next(); ok {
+			heads = append(heads, head{n, next})
+		}
+	}
+
+	// There are only ever a few runs, so the smallest head is found by
+	// looking at each
+	var last *big.Int
+	for len(heads) > 0 {
+		i := 0
+		for j := range heads {
+			if heads[j].n.Cmp(heads[i].n) < 0 {
+				i = j
+			}
+		}
+
+		if n := heads[i].n; last == nil || n.Cmp(last) != 0 {
+			if !fn(n) {
+				return false
+			}
+			last = n
+		}
+
+		if n, ok := heads[i].next(); ok {
+			heads[i].n = n
+		} else {
+			heads = append(heads[:i], heads[i+1:]...)
+		}
+	}
+	return true
+}
@@ -0,0 +1,166 @@
+// Package cli is the command line shared by both parts of day 02, which
+// differ only in which IDs they count as invalid by default.
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/dfryer1193/AoC-2025/02/ids"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+// Main runs day 02 with -rule defaulting to defaultRule.
+func Main(defaultRule string) {
+	ruleExpr := flag.String("rule", defaultRule, "which IDs are invalid: exactly:K, atleast:K, palindrome or digitsum<op>N, combined with and, or, not and parentheses")
+	explain := flag.String("explain", "", "list every invalid ID with its range, block and repetitions to this file (CSV, or JSON for .json; - for stdout)")
+	baseFlag := flag.Int("base", 10, "base the IDs are written in, from 2 to 36; repeated blocks are found in its digits")
+	overlapFlag := flag.String("overlap", "merge", "what to do with ranges that share IDs: merge (count each ID once), count (once per range) or reject")
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	rule, err := ids.ParseRule(*ruleExpr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error parsing rule:", err)
+		os.Exit(1)
+	}
+	base, err := ids.NewBase(*baseFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	overlap, err := ids.ParseOverlap(*overlapFlag)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := budget.Open(filename, stream.Lines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var explainer *ids.Explainer
+	if *explain != "" {
+		explainer, err = ids.CreateExplainer(*explain, base)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error creating explanation:", err)
+			os.Exit(1)
+		}
+	}
+
+	res, err := solve(budget.Scanner(f), settings{rule, base, overlap}, explainer)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+	if explainer != nil {
+		if err := explainer.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing explanation:", err)
+			os.Exit(1)
+		}
+	}
+
+	if res.duplicates != nil {
+		fmt.Println("Duplicates removed:", res.duplicates)
+	}
+	fmt.Println("Total sum:", res.sum)
+}
+
+// settings are what the command line chose.
+type settings struct {
+	rule    ids.Rule
+	base    ids.Base
+	overlap ids.Overlap
+}
+
+// result is what a run found. duplicates is only set when merging overlaps.
+type result struct {
+	sum        *big.Int
+	duplicates *big.Int
+}
+
+// solve reads the ranges from scanner and sums the invalid IDs in them,
+// explaining each if explainer is not nil. Its errors say what it was doing,
+// like "parsing range: ...".
+func solve(scanner *bufio.Scanner, s settings, explainer *ids.Explainer) (result, error) {
+	res := result{sum: new(big.Int)}
+
+	// The ranges all sit on one line, so read them one at a time rather than
+	// holding the whole line. Only counting overlaps lets each range be summed
+	// as soon as it is read; otherwise the ranges themselves are kept until
+	// the end, though not the IDs in them.
+	var ranges []ids.Range
+	records := stream.NewRecords(scanner)
+	for records.Scan() {
+		lo, hi, err := s.base.ParseRange(records.Line(), records.Text())
+		if err != nil {
+			return res, fmt.Errorf("parsing range: %w", err)
+		}
+		r := ids.Range{Lo: lo, Hi: hi, Text: records.Text()}
+		if s.overlap != ids.CountOverlaps {
+			ranges = append(ranges, r)
+			continue
+		}
+
+		total, err := processRange(s, r, explainer)
+		if err != nil {
+			return res, fmt.Errorf("writing explanation: %w", err)
+		}
+		res.sum.Add(res.sum, total.Sum)
+	}
+	if err := records.Err(); err != nil {
+		return res, fmt.Errorf("reading file: %w", err)
+	}
+
+	// Overlapping ranges would count their shared IDs twice, so when merging,
+	// the difference between the ranges' own counts and the merged count is
+	// the number of duplicates dropped.
+	separate := new(big.Int)
+	switch s.overlap {
+	case ids.RejectOverlaps:
+		if a, b, ok := ids.FindOverlap(ranges); ok {
+			return res, fmt.Errorf("checking ranges: %s and %s overlap", a.Text, b.Text)
+		}
+	case ids.MergeOverlaps:
+		for _, r := range ranges {
+			separate.Add(separate, s.base.Sum(r.Lo, r.Hi, s.rule).Count)
+		}
+		ranges = s.base.Merge(ranges)
+	}
+
+	count := new(big.Int)
+	for _, r := range ranges {
+		total, err := processRange(s, r, explainer)
+		if err != nil {
+			return res, fmt.Errorf("writing explanation: %w", err)
+		}
+		res.sum.Add(res.sum, total.Sum)
+		count.Add(count, total.Count)
+	}
+
+	if s.overlap == ids.MergeOverlaps {
+		res.duplicates = separate.Sub(separate, count)
+	}
+	return res, nil
+}
+
+// processRange totals the invalid IDs in one range, explaining each if asked.
+func processRange(s settings, r ids.Range, explainer *ids.Explainer) (ids.Total, error) {
+	if explainer != nil {
+		return explainer.Range(r.Text, r.Lo, r.Hi, s.rule)
+	}
+	return s.base.Sum(r.Lo, r.Hi, s.rule), nil
+}
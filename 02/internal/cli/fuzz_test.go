@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/02/ids"
+)
+
+// FuzzParseRanges runs both parts' rules over fuzzed range lists under each
+// way of handling overlaps, checking that bad input is reported rather than
+// crashing.
+func FuzzParseRanges(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	var rules []ids.Rule
+	for _, expr := range []string{"exactly:2", "atleast:2"} {
+		rule, err := ids.ParseRule(expr)
+		if err != nil {
+			f.Fatal(err)
+		}
+		rules = append(rules, rule)
+	}
+	base, err := ids.NewBase(10)
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, rule := range rules {
+			for _, overlap := range []ids.Overlap{ids.MergeOverlaps, ids.CountOverlaps, ids.RejectOverlaps} {
+				scanner := bufio.NewScanner(bytes.NewReader(input))
+				res, err := solve(scanner, settings{rule, base, overlap}, nil)
+				if err == nil && res.sum.Sign() < 0 {
+					t.Errorf("%v with %v: negative sum %v", rule, overlap, res.sum)
+				}
+			}
+		}
+	})
+}
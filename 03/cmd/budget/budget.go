@@ -0,0 +1,66 @@
+// Command budget shares a fixed number of batteries among all the day 03
+// banks, rather than switching on the same number in each, to make the
+// largest total joltage. Each bank may get anything from none to all of its
+// batteries:
+//
+//	go run ./03/cmd/budget -batteries 300 input.txt
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/dfryer1193/AoC-2025/03/joltage"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+func main() {
+	batteries := flag.Int("batteries", 0, "how many batteries to switch on across all banks")
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	if *batteries < 0 {
+		fmt.Fprintln(os.Stderr, "Please give a battery budget of zero or more.")
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	// Every bank's table is needed before any battery can be given out
+	filename := args[0]
+	f, err := budget.Open(filename, stream.WholeInput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var banks []string
+	var tables [][]*big.Int
+	scanner := budget.Scanner(f)
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if err := joltage.Validate(i, line, 0); err != nil {
+			fmt.Fprintln(os.Stderr, "Error parsing bank:", err)
+			os.Exit(1)
+		}
+		banks = append(banks, line)
+		tables = append(tables, joltage.Table(line))
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading file:", err)
+		os.Exit(1)
+	}
+
+	allocation, total := joltage.Allocate(tables, *batteries)
+	for i, bank := range banks {
+		fmt.Printf("Bank %s: %d batteries, joltage %s\n", bank, allocation[i], tables[i][allocation[i]])
+	}
+	fmt.Println("Total joltage:", total)
+}
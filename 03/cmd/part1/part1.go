@@ -0,0 +1,9 @@
+// Command part1 sums the largest joltage each day 03 bank makes, switching on
+// two batteries by default. See package cli for the flags.
+package main
+
+import "github.com/dfryer1193/AoC-2025/03/internal/cli"
+
+func main() {
+	cli.Main(2)
+}
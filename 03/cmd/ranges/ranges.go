@@ -0,0 +1,103 @@
+// Command ranges indexes one long day 03 bank and answers queries for the
+// best joltage from part of it. Each query is a line "l r k": the largest
+// k-digit joltage using only positions l to r, counting from 0 and
+// inclusive. Queries come from a file or, without one, from stdin:
+//
+//	go run ./03/cmd/ranges bank.txt queries.txt
+//	echo '0 99 12' | go run ./03/cmd/ranges bank.txt
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/03/joltage"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+func main() {
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide a bank filename.")
+		os.Exit(1)
+	}
+
+	// The index covers the whole bank at once
+	f, err := budget.Open(args[0], stream.WholeInput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	data, err := io.ReadAll(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading file:", err)
+		os.Exit(1)
+	}
+	bank := strings.TrimSpace(string(data))
+	if err := joltage.Validate(1, bank, 1); err != nil {
+		fmt.Fprintln(os.Stderr, "Error parsing bank:", err)
+		os.Exit(1)
+	}
+	index := joltage.NewIndex(bank)
+
+	queries := os.Stdin
+	if len(args) > 1 {
+		queries, err = os.Open(args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error opening queries:", err)
+			os.Exit(1)
+		}
+		defer queries.Close()
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	scanner := bufio.NewScanner(queries)
+	for i := 1; scanner.Scan(); i++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		answer, err := query(index, bank, fields)
+		if err != nil {
+			fmt.Fprintf(out, "Error: query %d: %v\n", i, err)
+			continue
+		}
+		fmt.Fprintln(out, answer)
+	}
+	if err := scanner.Err(); err != nil {
+		out.Flush()
+		fmt.Fprintln(os.Stderr, "Error reading queries:", err)
+		os.Exit(1)
+	}
+}
+
+func query(index *joltage.Index, bank string, fields []string) (string, error) {
+	if len(fields) != 3 {
+		return "", fmt.Errorf("expected l r k, got %q", strings.Join(fields, " "))
+	}
+	var nums [3]int
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return "", fmt.Errorf("invalid number %q", f)
+		}
+		nums[i] = n
+	}
+
+	picks, err := index.Peak(nums[0], nums[1], nums[2])
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Best %d from %d-%d: %s", nums[2], nums[0], nums[1], joltage.Joltage(bank, picks)), nil
+}
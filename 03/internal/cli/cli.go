@@ -0,0 +1,167 @@
+// Package cli is the command line shared by both parts of day 03, which
+// differ only in how many batteries they switch on by default.
+package cli
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/03/joltage"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+// verifyLimit is the longest bank -verify checks, since brute force tries
+// every choice of batteries.
+const verifyLimit = 20
+
+// Main runs day 03 with -digits defaulting to defaultDigits.
+func Main(defaultDigits int) {
+	digits := flag.Int("digits", defaultDigits, "how many batteries to switch on in each bank")
+	smallest := flag.Bool("smallest", false, "make the smallest joltage instead of the largest")
+	noLeadingZero := flag.Bool("no-leading-zero", false, "never start a joltage with a 0 battery")
+	minGap := flag.Int("min-gap", 1, "least distance between chosen positions")
+	maxSpan := flag.Int("max-span", 0, "greatest distance from the first chosen position to the last, 0 for no limit")
+	forbid := flag.String("forbid", "", "comma-separated positions, counting from 0, that may not be chosen")
+	verify := flag.Bool("verify", false, fmt.Sprintf("check the choice for banks of up to %d batteries against brute force", verifyLimit))
+	top := flag.Int("top", 0, "also list this many of the largest distinct joltages each bank can make")
+	show := flag.Bool("show", false, "print the chosen positions and mark the chosen batteries under each bank")
+	report := flag.String("report", "", "write each bank's chosen positions and joltage to this file (CSV, or JSON for .json; - for stdout)")
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	if *digits < 1 {
+		fmt.Fprintln(os.Stderr, "Please switch on at least one battery per bank.")
+		os.Exit(1)
+	}
+	opts := joltage.Options{Smallest: *smallest, NoLeadingZero: *noLeadingZero, MinGap: *minGap, MaxSpan: *maxSpan}
+	if *forbid != "" {
+		for _, field := range strings.Split(*forbid, ",") {
+			p, err := strconv.Atoi(strings.TrimSpace(field))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error parsing -forbid:", err)
+				os.Exit(1)
+			}
+			opts.Forbidden = append(opts.Forbidden, p)
+		}
+	}
+	if *top > 0 && (opts.Smallest || opts.Constrained()) {
+		fmt.Fprintln(os.Stderr, "-top lists the largest joltages without constraints.")
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := budget.Open(filename, stream.Lines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	var rep *joltage.Report
+	if *report != "" {
+		rep, err = joltage.CreateReport(*report)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error creating report:", err)
+			os.Exit(1)
+		}
+	}
+
+	s := settings{digits: *digits, opts: opts, verify: *verify, top: *top, show: *show}
+	res, err := solve(budget.Scanner(f), s, os.Stdout, rep)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+	if rep != nil {
+		if err := rep.Close(); err != nil {
+			fmt.Fprintln(os.Stderr, "Error writing report:", err)
+			os.Exit(1)
+		}
+	}
+
+	if *verify {
+		fmt.Println("Banks verified against brute force:", res.verified)
+	}
+	fmt.Println("Total sum:", res.sum)
+}
+
+// settings are what the command line chose.
+type settings struct {
+	digits int
+	opts   joltage.Options
+	verify bool
+	top    int
+	show   bool
+}
+
+type result struct {
+	sum      *big.Int
+	verified int
+}
+
+// solve reads banks from scanner, writing what it picks from each to out and
+// rep, if not nil, and sums their joltages. Its errors say what it was doing,
+// like "parsing bank: ...".
+func solve(scanner *bufio.Scanner, s settings, out io.Writer, rep *joltage.Report) (result, error) {
+	label := "Peak joltage"
+	if s.opts.Smallest {
+		label = "Least joltage"
+	}
+
+	// Joltages have as many digits as batteries switched on, which can be
+	// more than any integer type holds.
+	res := result{sum: new(big.Int)}
+
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if err := joltage.Validate(i, line, s.digits); err != nil {
+			return res, fmt.Errorf("parsing bank: %w", err)
+		}
+
+		picks, err := joltage.Select(line, s.digits, s.opts)
+		if err != nil {
+			return res, fmt.Errorf("choosing batteries: line %d: %w", i, err)
+		}
+		if s.verify && len(line) <= verifyLimit {
+			want, _ := joltage.BruteForce(line, s.digits, s.opts)
+			if !slices.Equal(picks, want) {
+				return res, fmt.Errorf("verifying line %d: chose %v, brute force chose %v", i, picks, want)
+			}
+			res.verified++
+		}
+
+		peak := joltage.Joltage(line, picks)
+		fmt.Fprintf(out, "%s for %s: %s\n", label, line, peak)
+		for rank, alt := range joltage.Top(line, s.digits, s.top) {
+			fmt.Fprintf(out, "  %d. %s at %v\n", rank+1, joltage.Joltage(line, alt), alt)
+		}
+		if s.show {
+			fmt.Fprintln(out, "Positions:", picks)
+			fmt.Fprintln(out, joltage.Render(line, picks))
+		}
+		if rep != nil {
+			if err := rep.Write(joltage.Choice{Line: i, Bank: line, Joltage: peak, Positions: picks}); err != nil {
+				return res, fmt.Errorf("writing report: %w", err)
+			}
+		}
+		n, _ := new(big.Int).SetString(peak, 10)
+		res.sum.Add(res.sum, n)
+	}
+	if err := scanner.Err(); err != nil {
+		return res, fmt.Errorf("reading file: %w", err)
+	}
+	return res, nil
+}
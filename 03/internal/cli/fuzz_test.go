@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+// FuzzParseBanks runs both parts over fuzzed banks, checking that bad input
+// is reported rather than crashing.
+func FuzzParseBanks(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for _, s := range []settings{
+			{digits: 2, top: 3, show: true},
+			{digits: 12, top: 3, show: true},
+		} {
+			scanner := bufio.NewScanner(bytes.NewReader(input))
+			solve(scanner, s, io.Discard, nil)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package joltage
+
+import "math/big"
+
+// Table returns the best joltage bank can make from every number of
+// batteries: index k holds the largest k-digit joltage, and index 0 is 0. It
+// runs Peak once per k, so a bank of n batteries costs O(n²), no more than
+// the digits in the table itself.
+func Table(bank string) []*big.Int {
+	table := make([]*big.Int, len(bank)+1)
+	table[0] = new(big.Int)
+	for k := 1; k <= len(bank); k++ {
+		table[k], _ = new(big.Int).SetString(Joltage(bank, Peak(bank, k)), 10)
+	}
+	return table
+}
+
+// Allocate shares a budget of batteries among banks, given each bank's
+// Table, to make the summed joltage as large as possible. It returns how many
+// batteries each bank gets and the sum they make.
+//
+// This is a knapsack over the banks in turn: best[b] is the largest sum the
+// banks so far make from b batteries in all, and each bank tries every share
+// it could add. That is O(banks × budget × bank length) big additions.
+func Allocate(tables [][]*big.Int, budget int) ([]int, *big.Int) {
+	best := make([]*big.Int, budget+1) // nil where b batteries can't be spent
+	best[0] = new(big.Int)
+	shares := make([][]int, len(tables)) // shares[i][b]: bank i's share when b are spent by banks 0..i
+
+	for i, table := range tables {
+		next := make([]*big.Int, budget+1)
+		shares[i] = make([]int, budget+1)
+		for b, sum := range best {
+			if sum == nil {
+				continue
+			}
+			for k := 0; k < len(table) && b+k <= budget; k++ {
+				v := new(big.Int).Add(sum, table[k])
+				if next[b+k] == nil || v.Cmp(next[b+k]) > 0 {
+					next[b+k] = v
+					shares[i][b+k] = k
+				}
+			}
+		}
+		best = next
+	}
+
+	spent := 0
+	for b, sum := range best {
+		if sum != nil && sum.Cmp(best[spent]) > 0 {
+			spent = b
+		}
+	}
+	total := best[spent]
+
+	allocation := make([]int, len(tables))
+	for i := len(tables) - 1; i >= 0; i-- {
+		allocation[i] = shares[i][spent]
+		spent -= allocation[i]
+	}
+	return allocation, total
+}
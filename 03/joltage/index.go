@@ -0,0 +1,78 @@
+package joltage
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// Index answers questions about the best choice from any stretch of one
+// bank. It keeps a sparse table of where the largest digit sits in every
+// stretch whose length is a power of two, leftmost on ties, so the largest
+// digit of any stretch is found in constant time from two overlapping ones.
+// Building it takes O(n log n) time and space for a bank of n batteries.
+type Index struct {
+	bank  string
+	table [][]int32 // table[j][i]: leftmost largest digit in bank[i : i+2^j]
+}
+
+// NewIndex builds an Index for bank.
+func NewIndex(bank string) *Index {
+	x := &Index{bank: bank}
+	if len(bank) == 0 {
+		return x
+	}
+
+	level := make([]int32, len(bank))
+	for i := range level {
+		level[i] = int32(i)
+	}
+	x.table = append(x.table, level)
+	for width := 2; width <= len(bank); width *= 2 {
+		prev := level
+		level = make([]int32, len(bank)-width+1)
+		for i := range level {
+			level[i] = x.better(prev[i], prev[i+width/2])
+		}
+		x.table = append(x.table, level)
+	}
+	return x
+}
+
+// better returns whichever of positions a < b holds the larger digit,
+// a on ties.
+func (x *Index) better(a, b int32) int32 {
+	if x.bank[b] > x.bank[a] {
+		return b
+	}
+	return a
+}
+
+// largest returns the leftmost position of the largest digit in l..r.
+func (x *Index) largest(l, r int) int {
+	j := bits.Len(uint(r-l+1)) - 1
+	return int(x.better(x.table[j][l], x.table[j][r-(1<<j)+1]))
+}
+
+// Peak returns the positions of the k batteries among positions l to r,
+// counting from 0 and inclusive, that make the largest joltage: the same
+// choice Peak makes for bank[l:r+1], but in O(k) after the Index is built.
+func (x *Index) Peak(l, r, k int) ([]int, error) {
+	if l > r {
+		return nil, fmt.Errorf("range %d-%d ends before it starts", l, r)
+	}
+	if l < 0 || r >= len(x.bank) {
+		return nil, fmt.Errorf("positions %d-%d are not within a bank of %d", l, r, len(x.bank))
+	}
+	if k < 1 || k > r-l+1 {
+		return nil, fmt.Errorf("can't choose %d batteries from %d", k, r-l+1)
+	}
+
+	// Each battery is the best that still leaves room for the rest
+	picks := make([]int, k)
+	from := l
+	for i := range picks {
+		picks[i] = x.largest(from, r-(k-1-i))
+		from = picks[i] + 1
+	}
+	return picks, nil
+}
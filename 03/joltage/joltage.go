@@ -0,0 +1,68 @@
+// Package joltage picks batteries from the banks of day 03. A bank is a line
+// of digits, and switching on k of its batteries makes the k-digit number
+// they read in order.
+package joltage
+
+import (
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// Validate checks that a bank is made only of digits and has at least k of
+// them to choose from.
+func Validate(lineNum int, bank string, k int) error {
+	if len(bank) < k {
+		return parse.Errorf(lineNum, bank, "bank needs at least %d batteries", k)
+	}
+	for _, c := range bank {
+		if c < '0' || c > '9' {
+			return parse.Errorf(lineNum, bank, "battery %q is not a digit", c)
+		}
+	}
+	return nil
+}
+
+// Peak returns the positions of the k batteries in bank that make the
+// largest number, in increasing order. Of several choices making the same
+// number, it takes the earliest positions.
+//
+// It keeps the picks so far on a stack, and each battery knocks smaller
+// digits off the top while enough batteries remain to refill it. Every
+// battery is pushed and popped at most once, so this is O(len(bank)) for any
+// k.
+func Peak(bank string, k int) []int {
+	return stack(bank, k, func(a, b byte) bool { return a > b })
+}
+
+// Least is Peak for the smallest number.
+func Least(bank string, k int) []int {
+	return stack(bank, k, func(a, b byte) bool { return a < b })
+}
+
+// stack picks k batteries, letting each battery replace those before it that
+// it beats.
+func stack(bank string, k int, beats func(a, b byte) bool) []int {
+	picks := make([]int, 0, k)
+	drop := len(bank) - k // Batteries that can still be left out
+	for i := 0; i < len(bank); i++ {
+		for drop > 0 && len(picks) > 0 && beats(bank[i], bank[picks[len(picks)-1]]) {
+			picks = picks[:len(picks)-1]
+			drop--
+		}
+		if len(picks) < k {
+			picks = append(picks, i)
+		} else {
+			drop--
+		}
+	}
+	return picks
+}
+
+// Joltage returns the number read from the batteries at picks. It is a
+// string since a long enough choice outgrows any integer type.
+func Joltage(bank string, picks []int) string {
+	b := make([]byte, len(picks))
+	for i, p := range picks {
+		b[i] = bank[p]
+	}
+	return string(b)
+}
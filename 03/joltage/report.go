@@ -0,0 +1,60 @@
+package joltage
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/report"
+)
+
+// Render returns bank with a second line marking the batteries at picks with
+// a caret beneath each.
+func Render(bank string, picks []int) string {
+	marks := []byte(strings.Repeat(" ", len(bank)))
+	for _, p := range picks {
+		marks[p] = '^'
+	}
+	return bank + "\n" + strings.TrimRight(string(marks), " ")
+}
+
+// Choice is the batteries picked from one bank. Positions count from 0.
+type Choice struct {
+	Line      int    `json:"line"`
+	Bank      string `json:"bank"`
+	Joltage   string `json:"joltage"`
+	Positions []int  `json:"positions"`
+}
+
+// Report writes the Choice made for every bank as it is made, as a report
+// (CSV, or JSON for .json). In CSV the positions are space-separated in one
+// column.
+type Report struct {
+	out *report.Writer
+}
+
+// CreateReport starts a report at path ("-" for stdout).
+func CreateReport(path string) (*Report, error) {
+	out, err := report.Create(path, "line", "bank", "joltage", "positions")
+	if err != nil {
+		return nil, err
+	}
+	return &Report{out: out}, nil
+}
+
+// Write adds one bank's choice to the report.
+func (r *Report) Write(c Choice) error {
+	if r.out.JSON() {
+		return r.out.Item(c)
+	}
+
+	positions := make([]string, len(c.Positions))
+	for i, p := range c.Positions {
+		positions[i] = strconv.Itoa(p)
+	}
+	return r.out.Row(strconv.Itoa(c.Line), c.Bank, c.Joltage, strings.Join(positions, " "))
+}
+
+// Close finishes the report.
+func (r *Report) Close() error {
+	return r.out.Close()
+}
@@ -0,0 +1,188 @@
+package joltage
+
+import (
+	"errors"
+	"slices"
+)
+
+// ErrNoChoice is returned when no k batteries of a bank meet the options.
+var ErrNoChoice = errors.New("no choice of batteries meets the constraints")
+
+// Options changes what Select looks for.
+type Options struct {
+	Smallest      bool  // make the smallest joltage rather than the largest
+	NoLeadingZero bool  // the first battery may not be a 0
+	MinGap        int   // chosen positions at least this far apart; 0 or 1 allows neighbours
+	MaxSpan       int   // last chosen position at most this far from the first; 0 for no limit
+	Forbidden     []int // positions that may not be chosen
+}
+
+// Constrained reports whether o limits which batteries may be chosen.
+func (o Options) Constrained() bool {
+	return o.NoLeadingZero || o.MinGap > 1 || o.MaxSpan > 0 || len(o.Forbidden) > 0
+}
+
+// better reports whether digit a beats digit b for the goal.
+func (o Options) better(a, b byte) bool {
+	if o.Smallest {
+		return a < b
+	}
+	return a > b
+}
+
+// Select returns the positions of the k batteries in bank making the best
+// joltage allowed by o, earliest positions first among equals.
+//
+// Without constraints this is Peak or Least. Otherwise the same greedy
+// choice of the best digit in turn still works, as long as it only considers
+// batteries that leave enough room for the rest: a table of how many
+// batteries can still be picked from each position on, filled from the right,
+// says which those are. A span limit breaks the greedy, since the first
+// battery it takes may sit where the span shuts out better ones later, so
+// then every window of the allowed span is tried and the best kept.
+func Select(bank string, k int, o Options) ([]int, error) {
+	if k == 0 {
+		// Switching nothing on needs no room, even in an empty bank
+		return []int{}, nil
+	}
+	if !o.Constrained() {
+		if o.Smallest {
+			return Least(bank, k), nil
+		}
+		return Peak(bank, k), nil
+	}
+
+	forbidden := make([]bool, len(bank))
+	for _, p := range o.Forbidden {
+		if p >= 0 && p < len(bank) {
+			forbidden[p] = true
+		}
+	}
+
+	if o.MaxSpan <= 0 {
+		picks := o.greedy(bank, k, forbidden, 0, len(bank)-1)
+		if picks == nil {
+			return nil, ErrNoChoice
+		}
+		return picks, nil
+	}
+
+	var best []int
+	var bestJoltage string
+	for lo := range bank {
+		if forbidden[lo] {
+			continue
+		}
+		picks := o.greedy(bank, k, forbidden, lo, min(lo+o.MaxSpan, len(bank)-1))
+		if picks == nil {
+			continue
+		}
+		j := Joltage(bank, picks)
+		if best == nil || o.beats(j, bestJoltage) || j == bestJoltage && slices.Compare(picks, best) < 0 {
+			best, bestJoltage = picks, j
+		}
+	}
+	if best == nil {
+		return nil, ErrNoChoice
+	}
+	return best, nil
+}
+
+// beats reports whether joltage a beats b for the goal. Both have the same
+// number of digits, so they compare as strings.
+func (o Options) beats(a, b string) bool {
+	if o.Smallest {
+		return a < b
+	}
+	return a > b
+}
+
+// greedy picks k batteries from positions lo to hi, taking the best digit
+// it can at each step, or returns nil if k can't be picked.
+func (o Options) greedy(bank string, k int, forbidden []bool, lo, hi int) []int {
+	gap := max(o.MinGap, 1)
+
+	// reach[p-lo] is the most batteries that can be picked from p to hi
+	reach := make([]int, hi-lo+2)
+	at := func(p int) int {
+		if p > hi {
+			return 0
+		}
+		return reach[p-lo]
+	}
+	for p := hi; p >= lo; p-- {
+		reach[p-lo] = at(p + 1)
+		if !forbidden[p] {
+			reach[p-lo] = max(reach[p-lo], 1+at(p+gap))
+		}
+	}
+
+	picks := make([]int, 0, k)
+	for from := lo; len(picks) < k; {
+		best := -1
+		for p := from; p <= hi; p++ {
+			if forbidden[p] || 1+at(p+gap) < k-len(picks) {
+				continue
+			}
+			if len(picks) == 0 && o.NoLeadingZero && bank[p] == '0' {
+				continue
+			}
+			if best < 0 || o.better(bank[p], bank[best]) {
+				best = p
+			}
+		}
+		if best < 0 {
+			return nil
+		}
+		picks = append(picks, best)
+		from = best + gap
+	}
+	return picks
+}
+
+// BruteForce finds what Select does by trying every choice of k batteries,
+// which is only practical for short banks. It is there to check Select.
+func BruteForce(bank string, k int, o Options) ([]int, error) {
+	if k == 0 {
+		// There is no first or last pick for the constraints to check
+		return []int{}, nil
+	}
+
+	forbidden := make(map[int]bool)
+	for _, p := range o.Forbidden {
+		forbidden[p] = true
+	}
+	gap := max(o.MinGap, 1)
+
+	var best []int
+	var bestJoltage string
+	picks := make([]int, 0, k)
+	var try func(from int)
+	try = func(from int) {
+		if len(picks) == k {
+			if o.MaxSpan > 0 && picks[k-1]-picks[0] > o.MaxSpan ||
+				o.NoLeadingZero && bank[picks[0]] == '0' {
+				return
+			}
+			j := Joltage(bank, picks)
+			if best == nil || o.beats(j, bestJoltage) {
+				best, bestJoltage = slices.Clone(picks), j
+			}
+			return
+		}
+		for p := from; p < len(bank); p++ {
+			if forbidden[p] {
+				continue
+			}
+			picks = append(picks, p)
+			try(p + gap)
+			picks = picks[:len(picks)-1]
+		}
+	}
+	try(0)
+
+	if best == nil {
+		return nil, ErrNoChoice
+	}
+	return best, nil
+}
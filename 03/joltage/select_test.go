@@ -0,0 +1,96 @@
+package joltage
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSelectMatchesBruteForce(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func(r *rand.Rand, n int) Options
+	}{
+		{"none", func(*rand.Rand, int) Options { return Options{} }},
+		{"smallest", func(*rand.Rand, int) Options { return Options{Smallest: true} }},
+		{"no leading zero", func(*rand.Rand, int) Options { return Options{NoLeadingZero: true} }},
+		{"smallest no leading zero", func(*rand.Rand, int) Options {
+			return Options{Smallest: true, NoLeadingZero: true}
+		}},
+		{"min gap", func(r *rand.Rand, _ int) Options { return Options{MinGap: 2 + r.IntN(3)} }},
+		{"max span", func(r *rand.Rand, n int) Options { return Options{MaxSpan: 1 + r.IntN(n)} }},
+		{"forbidden", func(r *rand.Rand, n int) Options { return Options{Forbidden: randomPositions(r, n)} }},
+		{"all", func(r *rand.Rand, n int) Options {
+			return Options{
+				Smallest:      r.IntN(2) == 0,
+				NoLeadingZero: r.IntN(2) == 0,
+				MinGap:        r.IntN(4),
+				MaxSpan:       r.IntN(n + 1),
+				Forbidden:     randomPositions(r, n),
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewPCG(2025, 3))
+			for range 2000 {
+				n := 1 + r.IntN(12)
+				bank := randomBank(r, n)
+				k := 1 + r.IntN(n)
+				o := tt.options(r, n)
+
+				got, err := Select(bank, k, o)
+				want, wantErr := BruteForce(bank, k, o)
+				if !errors.Is(err, wantErr) || !slices.Equal(got, want) {
+					t.Fatalf("Select(%q, %d, %+v) = %v, %v, want %v, %v", bank, k, o, got, err, want, wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectNone(t *testing.T) {
+	options := []Options{
+		{},
+		{Smallest: true},
+		{NoLeadingZero: true},
+		{MinGap: 2},
+		{MaxSpan: 3},
+		{Forbidden: []int{0}},
+		{Smallest: true, NoLeadingZero: true, MinGap: 2, MaxSpan: 3, Forbidden: []int{1}},
+	}
+
+	for _, bank := range []string{"", "0", "0912"} {
+		for _, o := range options {
+			for name, fn := range map[string]func(string, int, Options) ([]int, error){"Select": Select, "BruteForce": BruteForce} {
+				got, err := fn(bank, 0, o)
+				if err != nil || len(got) != 0 {
+					t.Errorf("%s(%q, 0, %+v) = %v, %v, want no picks", name, bank, o, got, err)
+				}
+			}
+		}
+	}
+}
+
+// randomBank returns n random digits, drawn from a few values so that ties
+// and zeros are common.
+func randomBank(r *rand.Rand, n int) string {
+	var b strings.Builder
+	for range n {
+		b.WriteByte("0129"[r.IntN(4)])
+	}
+	return b.String()
+}
+
+func randomPositions(r *rand.Rand, n int) []int {
+	var ps []int
+	for p := range n {
+		if r.IntN(4) == 0 {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
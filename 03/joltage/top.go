@@ -0,0 +1,50 @@
+package joltage
+
+// Top returns up to n distinct k-digit joltages from bank, largest first, as
+// the positions making each. Of several ways to make a joltage it gives the
+// earliest positions, so the first is what Peak picks.
+//
+// It walks the tree of joltages by prefix, trying larger digits first, which
+// meets them in descending order. Each digit is taken from its earliest
+// position after the previous one, skipping any too late to leave room for
+// the rest, so every branch leads to a joltage and none is reached twice:
+// the walk costs O(n × k × 10) after an O(len(bank)) table of where each
+// digit next appears, however many choices of k batteries there are.
+func Top(bank string, k, n int) [][]int {
+	// next[p][d] is the first position from p holding digit d, or -1
+	next := make([][10]int32, len(bank)+1)
+	for d := range next[len(bank)] {
+		next[len(bank)][d] = -1
+	}
+	for p := len(bank) - 1; p >= 0; p-- {
+		next[p] = next[p+1]
+		next[p][bank[p]-'0'] = int32(p)
+	}
+
+	var top [][]int
+	picks := make([]int, 0, k)
+	var walk func(from int) bool
+	walk = func(from int) bool {
+		if len(picks) == k {
+			top = append(top, append([]int(nil), picks...))
+			return len(top) < n
+		}
+		for d := 9; d >= 0; d-- {
+			p := int(next[from][d])
+			if p < 0 || len(bank)-p < k-len(picks) {
+				continue
+			}
+			picks = append(picks, p)
+			more := walk(p + 1)
+			picks = picks[:len(picks)-1]
+			if !more {
+				return false
+			}
+		}
+		return true
+	}
+	if n > 0 && k <= len(bank) {
+		walk(0)
+	}
+	return top
+}
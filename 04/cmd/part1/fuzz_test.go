@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseWarehouse(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		n, err := countAccessible(bufio.NewScanner(bytes.NewReader(input)), 4, io.Discard)
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+		if boxes := bytes.Count(input, []byte("@")); n > boxes {
+			t.Errorf("%d accessible boxes out of %d", n, boxes)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func main() {
+	threshold := flag.Int("threshold", 4, "a box is accessible with fewer than this many neighbors")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	accessibleBoxes, err := countAccessible(bufio.NewScanner(f), *threshold, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Total accessible boxes:", accessibleBoxes)
+}
+
+// countAccessible reads the warehouse from scanner a row at a time, reporting
+// each accessible box to out, and returns how many there are. Its errors say
+// what it was doing, like "parsing warehouse: ...".
+func countAccessible(scanner *bufio.Scanner, threshold int, out io.Writer) (int, error) {
+	accessibleBoxes := 0
+	warehouse := make([]string, 0)
+
+	hasMore := true
+	for i := 0; hasMore; {
+		hasMore = scanner.Scan()
+		if hasMore {
+			line := scanner.Text()
+			if err := validateRow(len(warehouse)+1, line); err != nil {
+				return 0, fmt.Errorf("parsing warehouse: %w", err)
+			}
+			warehouse = append(warehouse, line)
+		}
+		if len(warehouse) < 2 { // Need at least two rows to start checking
+			continue
+		}
+
+		fmt.Fprintln(out, "Checking row:", i, warehouse[i])
+		for j, c := range warehouse[i] {
+			if c != '@' {
+				continue
+			}
+
+			neighbors := countNeighbors(warehouse, j, i)
+			if neighbors < threshold {
+				fmt.Fprintln(out, i, j, "is accessible")
+				accessibleBoxes++
+			}
+		}
+
+		i++
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	return accessibleBoxes, nil
+}
+
+// validateRow checks that a row holds only boxes (@) and empty floor (.).
+func validateRow(lineNum int, row string) error {
+	for _, c := range row {
+		if c != '@' && c != '.' {
+			return parse.Errorf(lineNum, row, "unexpected %q in warehouse", c)
+		}
+	}
+	return nil
+}
+
+func countNeighbors(warehouse []string, x int, y int) int {
+	neighbors := 0
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			nx, ny := x+i, y+j
+
+			if ny >= 0 && ny < len(warehouse) && nx >= 0 && nx < len(warehouse[ny]) {
+				if warehouse[ny][nx] == '@' {
+					neighbors++
+				}
+			}
+		}
+	}
+
+	return neighbors
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseWarehouse(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	// Random warehouses of a range of sizes and densities
+	r := rand.New(rand.NewPCG(2025, 4))
+	for range 50 {
+		f.Add(randomWarehouse(r, 1+r.IntN(20), 1+r.IntN(20), r.Float64()))
+	}
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		warehouse, err := readWarehouse(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		for threshold := 0; threshold <= 9; threshold++ {
+			removedIn, rounds := removalRounds(warehouse, threshold)
+
+			// Replay the rounds by rescanning the whole warehouse each time,
+			// and check each takes out exactly the boxes removalRounds says
+			current := warehouse
+			for round := 1; ; round++ {
+				next, removed := removeAccessible(current, threshold)
+				if removed == 0 {
+					if round != rounds+1 {
+						t.Fatalf("threshold %d: rescanning took %d rounds, removalRounds %d", threshold, round-1, rounds)
+					}
+					break
+				}
+				for y, row := range current {
+					for x := range row {
+						gone := row[x] == '@' && next[y][x] != '@'
+						if gone != (removedIn[y][x] == round) {
+							t.Fatalf("threshold %d, round %d: box at %d,%d removed %v by rescanning, removalRounds says round %d",
+								threshold, round, x, y, gone, removedIn[y][x])
+						}
+					}
+				}
+				roundFrame(warehouse, removedIn, round)
+				current = next
+			}
+		}
+	})
+}
+
+// removeAccessible is one round of removal done by rescanning: every box
+// with fewer than threshold neighbors goes at once. It returns the
+// warehouse after the round and how many boxes it removed.
+func removeAccessible(warehouse []string, threshold int) ([]string, int) {
+	removed := 0
+	next := make([]string, len(warehouse))
+	for y, row := range warehouse {
+		nextRow := []byte(row)
+		for x := range row {
+			if row[x] == '@' && countNeighbors(warehouse, x, y) < threshold {
+				nextRow[x] = '.'
+				removed++
+			}
+		}
+		next[y] = string(nextRow)
+	}
+	return next, removed
+}
+
+// randomWarehouse draws a warehouse of w by h cells, each a box with
+// probability density.
+func randomWarehouse(r *rand.Rand, w, h int, density float64) []byte {
+	var b bytes.Buffer
+	for range h {
+		for range w {
+			if r.Float64() < density {
+				b.WriteByte('@')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.Bytes()
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/dfryer1193/AoC-2025/internal/anim"
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+func main() {
+	threshold := flag.Int("threshold", 4, "a box is accessible with fewer than this many neighbors")
+	showRounds := flag.Bool("rounds", false, "print how many boxes each round removed")
+	animOpts := anim.RegisterFlags()
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	// Boxes are removed all over the warehouse, so it all has to be in memory.
+	f, err := budget.Open(filename, stream.WholeInput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	warehouse, err := readWarehouse(budget.Scanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	rec, err := animOpts.Open("Day 4 part 2")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening recording:", err)
+		os.Exit(1)
+	}
+	defer rec.Close()
+
+	if err := rec.Frame(warehouse, "Round 0"); err != nil {
+		fmt.Fprintln(os.Stderr, "Error recording frame:", err)
+		os.Exit(1)
+	}
+
+	removedIn, rounds := removalRounds(warehouse, *threshold)
+	removed := make([]int, rounds+1)
+	for _, row := range removedIn {
+		for _, round := range row {
+			if round > 0 {
+				removed[round]++
+			}
+		}
+	}
+
+	accessibleBoxes := 0
+	for round := 1; round <= rounds; round++ {
+		accessibleBoxes += removed[round]
+		if *showRounds {
+			fmt.Printf("Round %d: removed %d boxes\n", round, removed[round])
+		}
+
+		if rec.Enabled() {
+			caption := fmt.Sprintf("Round %d: removed %d boxes (total %d)", round, removed[round], accessibleBoxes)
+			if err := rec.Frame(roundFrame(warehouse, removedIn, round), caption); err != nil {
+				fmt.Fprintln(os.Stderr, "Error recording frame:", err)
+				os.Exit(1)
+			}
+		}
+	}
+
+	fmt.Println("Total accessible boxes:", accessibleBoxes)
+}
+
+// readWarehouse reads the warehouse rows from scanner. Its errors say what it
+// was doing, like "parsing warehouse: ...".
+func readWarehouse(scanner *bufio.Scanner) ([]string, error) {
+	warehouse := make([]string, 0)
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if err := validateRow(i, line); err != nil {
+			return nil, fmt.Errorf("parsing warehouse: %w", err)
+		}
+		warehouse = append(warehouse, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return warehouse, nil
+}
+
+// removalRounds works out the round in which each box is removed, 0 for
+// boxes that stay, and how many rounds there were. Each round removes every
+// box that had fewer than threshold neighbors when the round began.
+//
+// Rather than rescanning the warehouse every round, it keeps each box's
+// neighbor count and only looks again at the neighbors of the boxes just
+// removed. Counts only go down, so a box joins the next round at the moment
+// its count drops below threshold, and the work is proportional to the boxes
+// and their neighbors rather than to rounds times cells.
+func removalRounds(warehouse []string, threshold int) ([][]int, int) {
+	type cell struct{ x, y int }
+
+	counts := make([][]int, len(warehouse))
+	removedIn := make([][]int, len(warehouse))
+	var queue []cell
+	for y, row := range warehouse {
+		counts[y] = make([]int, len(row))
+		removedIn[y] = make([]int, len(row))
+		for x := range row {
+			if row[x] != '@' {
+				continue
+			}
+			counts[y][x] = countNeighbors(warehouse, x, y)
+			if counts[y][x] < threshold {
+				queue = append(queue, cell{x, y})
+			}
+		}
+	}
+
+	rounds := 0
+	for len(queue) > 0 {
+		rounds++
+		// Take the whole round out before touching any counts, so every box
+		// in it is judged by the warehouse as the round began
+		for _, c := range queue {
+			removedIn[c.y][c.x] = rounds
+		}
+
+		var next []cell
+		for _, c := range queue {
+			eachNeighbor(warehouse, c.x, c.y, func(nx, ny int) {
+				if warehouse[ny][nx] != '@' || removedIn[ny][nx] != 0 {
+					return
+				}
+				counts[ny][nx]--
+				if counts[ny][nx] == threshold-1 {
+					next = append(next, cell{nx, ny})
+				}
+			})
+		}
+		queue = next
+	}
+
+	return removedIn, rounds
+}
+
+// roundFrame draws the warehouse as it was during round, with the boxes that
+// round removes drawn as 'x' and those removed earlier gone.
+func roundFrame(warehouse []string, removedIn [][]int, round int) []string {
+	frame := make([]string, len(warehouse))
+	for y, row := range warehouse {
+		framed := []byte(row)
+		for x, r := range removedIn[y] {
+			switch {
+			case r == 0 || r > round:
+			case r == round:
+				framed[x] = 'x'
+			default:
+				framed[x] = '.'
+			}
+		}
+		frame[y] = string(framed)
+	}
+
+	return frame
+}
+
+// validateRow checks that a row holds only boxes (@) and empty floor (.).
+func validateRow(lineNum int, row string) error {
+	for _, c := range row {
+		if c != '@' && c != '.' {
+			return parse.Errorf(lineNum, row, "unexpected %q in warehouse", c)
+		}
+	}
+	return nil
+}
+
+func countNeighbors(warehouse []string, x int, y int) int {
+	neighbors := 0
+	eachNeighbor(warehouse, x, y, func(nx, ny int) {
+		if warehouse[ny][nx] == '@' {
+			neighbors++
+		}
+	})
+
+	return neighbors
+}
+
+// eachNeighbor calls fn with each of the up to eight cells around x, y that
+// lie inside the warehouse.
+func eachNeighbor(warehouse []string, x int, y int, fn func(nx, ny int)) {
+	for i := -1; i <= 1; i++ {
+		for j := -1; j <= 1; j++ {
+			if i == 0 && j == 0 {
+				continue
+			}
+			nx, ny := x+i, y+j
+
+			if ny >= 0 && ny < len(warehouse) && nx >= 0 && nx < len(warehouse[ny]) {
+				fn(nx, ny)
+			}
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseInventory(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		_, err := countFresh(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil && !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+			t.Fatalf("error is not a *parse.Error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+type parseState int
+
+const (
+	parsingFreshnessRanges parseState = iota
+	parsingItemIDs
+)
+
+func main() {
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := budget.Open(filename, stream.Lines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	freshItemCount, err := countFresh(budget.Scanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Total fresh items: %d\n", freshItemCount)
+}
+
+// countFresh reads the freshness ranges and then the item IDs from scanner
+// and counts the IDs that fall in a range. Its errors say what it was doing,
+// like "parsing item ID: ...".
+func countFresh(scanner *bufio.Scanner) (int, error) {
+	parserState := parsingFreshnessRanges
+	ranges := make([][2]int, 0)
+	freshItemCount := 0
+
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if line == "" {
+			parserState = parsingItemIDs
+			continue
+		}
+
+		switch parserState {
+		case parsingFreshnessRanges:
+			min, max, err := parseFreshnessRange(i, line)
+			if err != nil {
+				return 0, fmt.Errorf("parsing freshness range: %w", err)
+			}
+			ranges = append(ranges, [2]int{min, max})
+		case parsingItemIDs:
+			id, err := parseItemID(i, line)
+			if err != nil {
+				return 0, fmt.Errorf("parsing item ID: %w", err)
+			}
+			if isFresh(id, ranges) {
+				freshItemCount++
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	return freshItemCount, nil
+}
+
+func parseFreshnessRange(lineNum int, line string) (int, int, error) {
+	from, to, ok := strings.Cut(line, "-")
+	if !ok {
+		return 0, 0, parse.Errorf(lineNum, line, "expected a range like 3-5")
+	}
+
+	min, err := parse.Int(lineNum, from)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := parse.Int(lineNum, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		return 0, 0, parse.Errorf(lineNum, line, "range end is before its start")
+	}
+
+	return min, max, nil
+}
+
+func parseItemID(lineNum int, line string) (int, error) {
+	return parse.Int(lineNum, line)
+}
+
+func isFresh(id int, ranges [][2]int) bool {
+	for _, r := range ranges {
+		if !(id < r[0] || id > r[1]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseInventory(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		ranges, err := readRanges(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		merged := mergeRanges(ranges)
+		for i := 1; i < len(merged); i++ {
+			if merged[i][0]-1 <= merged[i-1][1] {
+				t.Errorf("merged ranges %v and %v touch", merged[i-1], merged[i])
+			}
+		}
+	})
+}
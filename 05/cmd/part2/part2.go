@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func main() {
+	args := os.Args[1:]
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	ranges, err := readRanges(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	ranges = mergeRanges(ranges)
+
+	freshItemCount := 0
+	for _, r := range ranges {
+		freshItemCount += r[1] - r[0] + 1
+	}
+
+	fmt.Printf("Total possible fresh items: %d\n", freshItemCount)
+}
+
+// readRanges reads the freshness ranges from scanner, up to the blank line
+// before the item IDs. Its errors say what it was doing, like "parsing
+// freshness range: ...".
+func readRanges(scanner *bufio.Scanner) ([][2]int, error) {
+	ranges := make([][2]int, 0)
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+
+		if line == "" {
+			break
+		}
+
+		min, max, err := parseFreshnessRange(i, line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing freshness range: %w", err)
+		}
+
+		ranges = append(ranges, [2]int{min, max})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return ranges, nil
+}
+
+func parseFreshnessRange(lineNum int, line string) (int, int, error) {
+	from, to, ok := strings.Cut(line, "-")
+	if !ok {
+		return 0, 0, parse.Errorf(lineNum, line, "expected a range like 3-5")
+	}
+
+	min, err := parse.Int(lineNum, from)
+	if err != nil {
+		return 0, 0, err
+	}
+	max, err := parse.Int(lineNum, to)
+	if err != nil {
+		return 0, 0, err
+	}
+	if min > max {
+		return 0, 0, parse.Errorf(lineNum, line, "range end is before its start")
+	}
+
+	return min, max, nil
+}
+
+func mergeRanges(ranges [][2]int) [][2]int {
+	if len(ranges) == 0 {
+		return ranges
+	}
+	// Sort ranges by start so a single linear pass can merge all overlaps
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+
+	merged := make([][2]int, 0, len(ranges))
+
+	cur := ranges[0]
+	for i := 1; i < len(ranges); i++ {
+		r := ranges[i]
+		// Starts are never negative, so r[0]-1 can't overflow as cur[1]+1
+		// would for a range ending at the largest int
+		if r[0]-1 > cur[1] {
+			// disjoint
+			merged = append(merged, cur)
+			cur = r
+		} else {
+			// overlapping or adjacent: merge
+			if r[1] > cur[1] {
+				cur[1] = r[1]
+			}
+		}
+	}
+	merged = append(merged, cur)
+
+	return merged
+}
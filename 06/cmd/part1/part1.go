@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+type operation int
+
+const (
+	add operation = iota
+	multiply
+)
+
+// equation keeps running totals for one column rather than its values. The
+// operator comes last, so both the sum and the product are carried along.
+type equation struct {
+	count   int
+	sum     int
+	product int
+	op      operation
+}
+
+func main() {
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := budget.Open(filename, stream.Lines)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	accumulator, err := evaluate(budget.Scanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Final accumulator value:", accumulator)
+}
+
+// evaluate reads the worksheet from scanner and adds up the value of every
+// column. Its errors say what it was doing, like "parsing worksheet: ...".
+func evaluate(scanner *bufio.Scanner) (int, error) {
+	accumulator := 0
+	eqs := make([]*equation, 0)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+
+		for i, field := range strings.Fields(line) {
+			if i > len(eqs)-1 {
+				eqs = append(eqs, &equation{op: add})
+			}
+
+			eq := eqs[i]
+
+			val, err := strconv.Atoi(field)
+			if err != nil {
+				if field == "+" {
+					eq.op = add
+				} else if field == "*" {
+					eq.op = multiply
+				} else {
+					return 0, fmt.Errorf("parsing worksheet: %w", parse.Errorf(lineNum, field, "unknown operator"))
+				}
+				continue
+			}
+
+			eq.count++
+			eq.sum += val
+			if eq.product == 0 {
+				eq.product = 1
+			}
+			eq.product *= val
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	for _, eq := range eqs {
+		if eq.count == 0 {
+			continue
+		}
+
+		if eq.op == add {
+			accumulator += eq.sum
+		} else if eq.op == multiply {
+			accumulator += eq.product
+		}
+	}
+
+	return accumulator, nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+	"github.com/dfryer1193/AoC-2025/internal/stream"
+)
+
+type operation int
+
+const (
+	add operation = iota
+	multiply
+)
+
+type equation struct {
+	values   []int
+	op       operation
+	rawVals  []string
+	numCount int
+}
+
+func main() {
+	budget := stream.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	// Numbers read down the columns, so every line is kept until the operator
+	// row says where the columns are.
+	f, err := budget.Open(filename, stream.WholeInput)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	rawLines, eqs, err := readWorksheet(budget.Scanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+	accumulator, err := evaluate(rawLines, eqs)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Final accumulator value:", accumulator)
+}
+
+// readWorksheet reads the worksheet from scanner, returning its lines and an
+// equation for each column holding the column's numbers as written. Its
+// errors say what it was doing, like "parsing worksheet: ...".
+func readWorksheet(scanner *bufio.Scanner) ([]string, []*equation, error) {
+	rawLines := make([]string, 0)
+	eqs := make([]*equation, 0)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if err := validateLine(lineNum, line); err != nil {
+			return nil, nil, fmt.Errorf("parsing worksheet: %w", err)
+		}
+
+		rawLines = append(rawLines, line)
+		fields := strings.Fields(line)
+		for i, field := range fields {
+			if i > len(eqs)-1 {
+				eqs = append(eqs, &equation{
+					values:   make([]int, 0),
+					op:       add,
+					rawVals:  make([]string, 0),
+					numCount: 0,
+				})
+			}
+
+			eq := eqs[i]
+
+			if field == "+" {
+				eq.op = add
+			} else if field == "*" {
+				eq.op = multiply
+			} else {
+				if len(field) > eq.numCount {
+					eq.numCount = len(field)
+				}
+				eq.rawVals = append(eq.rawVals, field)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return rawLines, eqs, nil
+}
+
+// evaluate reads the numbers down the columns of the worksheet's lines,
+// right to left, and adds up the value of every column. It fails like
+// readWorksheet if a line is too short to hold a column.
+func evaluate(rawLines []string, eqs []*equation) (int, error) {
+	accumulator := 0
+
+	// Columns are sliced off the right-hand end, so every line needs the same
+	// width even if an editor trimmed its trailing spaces.
+	width := 0
+	for _, l := range rawLines {
+		width = max(width, len(l))
+	}
+	for j, l := range rawLines {
+		rawLines[j] = l + strings.Repeat(" ", width-len(l))
+	}
+
+	for i := len(eqs) - 1; i >= 0; i-- {
+		eq := eqs[i]
+		lsum := 0
+		vnums := make([]string, 0)
+		eq.values = make([]int, eq.numCount)
+		for j, l := range rawLines {
+			if len(l) < eq.numCount {
+				return 0, fmt.Errorf("parsing worksheet: %w", parse.Errorf(j+1, l, "line too short for column %d", i+1))
+			}
+			vnums = append(vnums, l[len(l)-eq.numCount:])
+			rawLines[j] = l[:len(l)-eq.numCount] // remove processed part
+			if len(rawLines[j]) > 0 {
+				rawLines[j] = rawLines[j][:len(rawLines[j])-1] // remove trailing space
+			}
+		}
+
+		for j := eq.numCount - 1; j >= 0; j-- {
+			for _, vnum := range vnums {
+				if vnum[j] == ' ' {
+					continue
+				}
+
+				if vnum[j] == '+' {
+					eq.op = add
+				} else if vnum[j] == '*' {
+					eq.op = multiply
+				} else {
+					digit := int(vnum[j] - '0')
+					eq.values[j] *= 10
+					eq.values[j] += digit
+				}
+			}
+		}
+
+		for _, val := range eq.values {
+			if eq.op == add {
+				lsum += val
+			} else if eq.op == multiply {
+				if lsum == 0 {
+					lsum = 1
+				}
+				lsum *= val
+			}
+		}
+		accumulator += lsum
+	}
+
+	return accumulator, nil
+}
+
+// validateLine checks that a worksheet line holds only digits, spaces and operators.
+func validateLine(lineNum int, line string) error {
+	for _, c := range line {
+		if c != ' ' && c != '+' && c != '*' && (c < '0' || c > '9') {
+			return parse.Errorf(lineNum, line, "unexpected %q in worksheet", c)
+		}
+	}
+	return nil
+}
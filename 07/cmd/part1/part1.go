@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/anim"
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func main() {
+	animOpts := anim.RegisterFlags()
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	rec, err := animOpts.Open("Day 7 part 1")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening recording:", err)
+		os.Exit(1)
+	}
+	defer rec.Close()
+
+	manifold, beamLocs, splits, err := traceBeams(bufio.NewScanner(f), rec.Enabled())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	for i := range manifold {
+		caption := fmt.Sprintf("Row %d: %d beams", i, len(beamLocs[i]))
+		if err := rec.Frame(beamFrame(manifold, beamLocs, i), caption); err != nil {
+			fmt.Fprintln(os.Stderr, "Error recording frame:", err)
+			os.Exit(1)
+		}
+	}
+
+	fmt.Println("Number of splits:", splits)
+}
+
+// traceBeams follows the beams down the manifold read from scanner, returning
+// the beam positions in each row and how many times a beam was split. The
+// manifold's rows are returned too if keep is set. Its errors say what it
+// was doing, like "parsing manifold: ...".
+func traceBeams(scanner *bufio.Scanner, keep bool) ([]string, []map[int]struct{}, int, error) {
+	// Only keep the manifold around if we need to draw it afterwards
+	manifold := make([]string, 0)
+
+	beamLocs := make([]map[int]struct{}, 0)
+	splits := 0
+
+	width := -1
+	for i := 0; scanner.Scan(); i++ {
+		line := scanner.Text()
+		if err := validateRow(i+1, line, width); err != nil {
+			return nil, nil, 0, fmt.Errorf("parsing manifold: %w", err)
+		}
+		width = len(line)
+
+		if keep {
+			manifold = append(manifold, line)
+		}
+
+		if len(beamLocs) == 0 {
+			beamLocs = append(beamLocs, make(map[int]struct{}))
+			startLoc := strings.Index(line, "S")
+			if startLoc == -1 {
+				return nil, nil, 0, fmt.Errorf("parsing manifold: %w", parse.Errorf(1, line, "no start (S) in first row"))
+			}
+			beamLocs[0][startLoc] = struct{}{}
+			continue
+		}
+
+		beamLocs = append(beamLocs, make(map[int]struct{}))
+		for idx := range beamLocs[i-1] {
+			if line[idx] == '.' {
+				beamLocs[i][idx] = struct{}{}
+			}
+
+			if line[idx] == '^' {
+				splits++
+				if idx > 0 && line[idx-1] == '.' {
+					beamLocs[i][idx-1] = struct{}{}
+				}
+
+				if idx < len(line)-1 && line[idx+1] == '.' {
+					beamLocs[i][idx+1] = struct{}{}
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("reading file: %w", err)
+	}
+
+	return manifold, beamLocs, splits, nil
+}
+
+// validateRow checks that a manifold row holds only empty space, splitters and
+// the start, and is as wide as the rows before it (width is -1 for the first row).
+func validateRow(lineNum int, row string, width int) error {
+	if width != -1 && len(row) != width {
+		return parse.Errorf(lineNum, row, "row is %d wide, expected %d", len(row), width)
+	}
+	for _, c := range row {
+		if c != '.' && c != '^' && c != 'S' {
+			return parse.Errorf(lineNum, row, "unexpected %q in manifold", c)
+		}
+	}
+	return nil
+}
+
+// beamFrame draws the manifold with the beams of every row up to and including row.
+func beamFrame(manifold []string, beamLocs []map[int]struct{}, row int) []string {
+	frame := make([]string, len(manifold))
+	copy(frame, manifold)
+	for i := 1; i <= row; i++ {
+		line := []rune(frame[i])
+		for idx := range beamLocs[i] {
+			line[idx] = '|'
+		}
+		frame[i] = string(line)
+	}
+
+	return frame
+}
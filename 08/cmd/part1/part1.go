@@ -2,12 +2,15 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"math"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
 )
 
 type junction struct {
@@ -37,6 +40,25 @@ type pair struct {
 	distance float64
 }
 
+// parseJunction reads a line of three comma separated coordinates.
+func parseJunction(lineNum int, line string) (int, int, int, error) {
+	coords := strings.Split(line, ",")
+	if len(coords) != 3 {
+		return 0, 0, 0, parse.Errorf(lineNum, line, "expected x,y,z")
+	}
+
+	var xyz [3]int
+	for i, c := range coords {
+		v, err := parse.Int(lineNum, c)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		xyz[i] = v
+	}
+
+	return xyz[0], xyz[1], xyz[2], nil
+}
+
 // DSU (Disjoint Set Union) functions
 func find(parents map[string]string, key string) string {
 	if parents[key] == key {
@@ -54,44 +76,19 @@ func union(parents map[string]string, a, b string) {
 	}
 }
 
-func main() {
-	args := os.Args[1:]
-	if len(args) < 2 {
-		fmt.Println("Usage: go run part1.go <filename> <connections_to_make>")
-		return
-	}
-
-	filename := args[0]
-	limitStr := args[1]
-	mergeLimit, err := strconv.Atoi(limitStr)
-	if err != nil {
-		fmt.Println("Error: Invalid number for connections to make:", err)
-		return
-	}
-
-	f, err := os.Open(filename)
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer f.Close()
-
+// readJunctions reads the junctions from scanner, dropping repeats. Its errors
+// say what it was doing, like "parsing junction: ...".
+func readJunctions(scanner *bufio.Scanner) ([]*junction, error) {
 	junctions := make([]*junction, 0)
 	junctionMap := make(map[string]*junction)
 
-	scanner := bufio.NewScanner(f)
-	for i := 0; scanner.Scan(); i++ {
+	for i := 1; scanner.Scan(); i++ {
 		line := scanner.Text()
-		coords := strings.Split(line, ",")
-		if len(coords) != 3 {
-			fmt.Println("Invalid line:", line)
-			return
+		x, y, z, err := parseJunction(i, line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing junction: %w", err)
 		}
 
-		x, _ := strconv.Atoi(coords[0])
-		y, _ := strconv.Atoi(coords[1])
-		z, _ := strconv.Atoi(coords[2])
-
 		key := buildKey(x, y, z)
 		if _, ok := junctionMap[key]; ok {
 			continue
@@ -106,7 +103,16 @@ func main() {
 		junctions = append(junctions, j)
 		junctionMap[key] = j
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return junctions, nil
+}
 
+// circuitSizes connects the mergeLimit closest pairs of junctions and
+// returns the sizes of the circuits they form, largest first.
+func circuitSizes(junctions []*junction, mergeLimit int) []int {
 	// Generate all unique pairs
 	pairs := make([]pair, 0)
 	for i := 0; i < len(junctions); i++ {
@@ -157,9 +163,47 @@ func main() {
 	// Sort sizes in descending order
 	sort.Sort(sort.Reverse(sort.IntSlice(allSizes)))
 
+	return allSizes
+}
+
+func main() {
+	mergeLimit := flag.Int("connections", 1000, "number of shortest connections to make")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go run ./08/cmd/part1 [-connections n] <filename> [connections_to_make]")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	if len(args) > 1 {
+		// Still accept the connection count positionally, as before
+		limit, err := strconv.Atoi(args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error: Invalid number for connections to make:", err)
+			os.Exit(1)
+		}
+		*mergeLimit = limit
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	junctions, err := readJunctions(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	allSizes := circuitSizes(junctions, *mergeLimit)
 	if len(allSizes) < 3 {
-		fmt.Println("Error: Less than three circuits found.")
-		return
+		fmt.Fprintln(os.Stderr, "Error: Less than three circuits found.")
+		os.Exit(1)
 	}
 
 	// Multiply the sizes of the three largest circuits
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// maxFuzzJunctions keeps the all-pairs search quick enough to fuzz.
+const maxFuzzJunctions = 200
+
+func FuzzParseJunctions(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		junctions, err := readJunctions(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+		if len(junctions) < 2 {
+			return
+		}
+		if len(junctions) > maxFuzzJunctions {
+			t.Skip("too many junctions to connect quickly")
+		}
+
+		if p := lastConnection(junctions); p.a == nil || p.b == nil {
+			t.Errorf("no last connection between %d junctions", len(junctions))
+		}
+	})
+}
@@ -6,8 +6,9 @@ import (
 	"math"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
 )
 
 type junction struct {
@@ -37,6 +38,25 @@ type pair struct {
 	distance float64
 }
 
+// parseJunction reads a line of three comma separated coordinates.
+func parseJunction(lineNum int, line string) (int, int, int, error) {
+	coords := strings.Split(line, ",")
+	if len(coords) != 3 {
+		return 0, 0, 0, parse.Errorf(lineNum, line, "expected x,y,z")
+	}
+
+	var xyz [3]int
+	for i, c := range coords {
+		v, err := parse.Int(lineNum, c)
+		if err != nil {
+			return 0, 0, 0, err
+		}
+		xyz[i] = v
+	}
+
+	return xyz[0], xyz[1], xyz[2], nil
+}
+
 // DSU (Disjoint Set Union) functions
 func find(parents map[string]string, key string) string {
 	if parents[key] == key {
@@ -54,37 +74,19 @@ func union(parents map[string]string, a, b string) {
 	}
 }
 
-func main() {
-	args := os.Args[1:]
-	if len(args) < 1 {
-		fmt.Println("Usage: go run part2.go <filename>")
-		return
-	}
-
-	filename := args[0]
-	f, err := os.Open(filename)
-	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
-	}
-	defer f.Close()
-
+// readJunctions reads the junctions from scanner, dropping repeats. Its errors
+// say what it was doing, like "parsing junction: ...".
+func readJunctions(scanner *bufio.Scanner) ([]*junction, error) {
 	junctions := make([]*junction, 0)
 	junctionMap := make(map[string]*junction)
 
-	scanner := bufio.NewScanner(f)
-	for i := 0; scanner.Scan(); i++ {
+	for i := 1; scanner.Scan(); i++ {
 		line := scanner.Text()
-		coords := strings.Split(line, ",")
-		if len(coords) != 3 {
-			fmt.Println("Invalid line:", line)
-			return
+		x, y, z, err := parseJunction(i, line)
+		if err != nil {
+			return nil, fmt.Errorf("parsing junction: %w", err)
 		}
 
-		x, _ := strconv.Atoi(coords[0])
-		y, _ := strconv.Atoi(coords[1])
-		z, _ := strconv.Atoi(coords[2])
-
 		key := buildKey(x, y, z)
 		if _, ok := junctionMap[key]; ok {
 			continue
@@ -99,7 +101,16 @@ func main() {
 		junctions = append(junctions, j)
 		junctionMap[key] = j
 	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
 
+	return junctions, nil
+}
+
+// lastConnection connects the closest pairs of junctions in turn until they
+// form a single circuit, and returns the pair that completed it.
+func lastConnection(junctions []*junction) pair {
 	// Generate all unique pairs
 	pairs := make([]pair, 0)
 	for i := 0; i < len(junctions); i++ {
@@ -135,6 +146,37 @@ func main() {
 		}
 	}
 
+	return lastConnectedPair
+}
+
+func main() {
+	args := os.Args[1:]
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Usage: go run part2.go <filename>")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	junctions, err := readJunctions(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	if len(junctions) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: Need at least two distinct junctions to connect.")
+		os.Exit(1)
+	}
+
+	lastConnectedPair := lastConnection(junctions)
+
 	// The lastConnectedPair holds the two junctions that made the final connection
 	lastJunctionA := lastConnectedPair.a
 	lastJunctionB := lastConnectedPair.b
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func main() {
+	args := os.Args[1:]
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	points, err := readPoints(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Maximum rectangle area:", largestRectangle(points))
+}
+
+// readPoints reads the red tiles from scanner. Its errors say what it was
+// doing, like "converting coordinate: ...".
+func readPoints(scanner *bufio.Scanner) ([][2]int, error) {
+	points := make([][2]int, 0)
+
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+
+		coords, err := parsePoint(i, line)
+		if err != nil {
+			return nil, fmt.Errorf("converting coordinate: %w", err)
+		}
+		points = append(points, coords)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return points, nil
+}
+
+// largestRectangle returns the area of the largest rectangle with red tiles
+// at two opposite corners.
+func largestRectangle(points [][2]int) float64 {
+	maxRectArea := float64(0)
+	for _, p1 := range points {
+		for _, p2 := range points {
+			// Skip if points are aligned vertically or horizontally
+			if p1[0] == p2[0] || p1[1] == p2[1] {
+				continue
+			}
+
+			length := math.Max(float64(p1[0]), float64(p2[0])) -
+				math.Min(float64(p1[0]), float64(p2[0])) + 1
+			width := math.Max(float64(p1[1]), float64(p2[1])) -
+				math.Min(float64(p1[1]), float64(p2[1])) + 1
+
+			area := length * width
+			if area > maxRectArea {
+				maxRectArea = area
+			}
+		}
+	}
+
+	return maxRectArea
+}
+
+// parsePoint reads a line of two comma separated coordinates.
+func parsePoint(lineNum int, line string) ([2]int, error) {
+	var coords [2]int
+	strCoords := strings.Split(line, ",")
+	if len(strCoords) != 2 {
+		return coords, parse.Errorf(lineNum, line, "expected x,y")
+	}
+
+	for j, strCoord := range strCoords {
+		coord, err := parse.Int(lineNum, strCoord)
+		if err != nil {
+			return coords, err
+		}
+		coords[j] = coord
+	}
+	return coords, nil
+}
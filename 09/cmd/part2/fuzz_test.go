@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseRedTiles(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		redTiles, err := readRedTiles(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		// main rejects these before looking for rectangles
+		if len(redTiles) < 2 {
+			return
+		}
+		if first, last := redTiles[0], redTiles[len(redTiles)-1]; first[0] != last[0] && first[1] != last[1] {
+			return
+		}
+
+		largestInsideRectangle(redTiles)
+	})
+}
@@ -5,8 +5,9 @@ import (
 	"fmt"
 	"os"
 	"sort"
-	"strconv"
 	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
 )
 
 // Helper functions for min/max
@@ -27,39 +28,68 @@ func max(a, b int) int {
 func main() {
 	args := os.Args[1:]
 	if len(args) < 1 {
-		fmt.Println("Please provide an input filename.")
-		return
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
 	}
 
 	filename := args[0]
 	f, err := os.Open(filename)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
 	}
 	defer f.Close()
 
 	// 1. Read points (red tiles)
-	redTiles := make([][2]int, 0)
-	xCoordsSet := make(map[int]bool)
-	yCoordsSet := make(map[int]bool)
+	redTiles, err := readRedTiles(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	if len(redTiles) < 2 {
+		fmt.Fprintln(os.Stderr, "Error: Need at least two red tiles to form a loop.")
+		os.Exit(1)
+	}
+	if first, last := redTiles[0], redTiles[len(redTiles)-1]; first[0] != last[0] && first[1] != last[1] {
+		fmt.Fprintln(os.Stderr, "Error: The last red tile is not in line with the first.")
+		os.Exit(1)
+	}
+
+	fmt.Println("Maximum rectangle area:", largestInsideRectangle(redTiles))
+}
 
-	scanner := bufio.NewScanner(f)
-	for scanner.Scan() {
+// readRedTiles reads the corners of the loop from scanner, each in line with
+// the one before. Its errors say what it was doing, like "converting
+// coordinate: ...".
+func readRedTiles(scanner *bufio.Scanner) ([][2]int, error) {
+	redTiles := make([][2]int, 0)
+	for i := 1; scanner.Scan(); i++ {
 		line := scanner.Text()
-		strCoords := strings.Split(line, ",")
-		var coords [2]int
-		for j, strCoord := range strCoords {
-			coord, err := strconv.Atoi(strCoord)
-			if err != nil {
-				fmt.Println("Error converting coordinate:", err)
-				return
-			}
-			coords[j] = coord
+		coords, err := parsePoint(i, line)
+		if err != nil {
+			return nil, fmt.Errorf("converting coordinate: %w", err)
+		}
+		if n := len(redTiles); n > 0 && redTiles[n-1][0] != coords[0] && redTiles[n-1][1] != coords[1] {
+			return nil, fmt.Errorf("converting coordinate: %w", parse.Errorf(i, line, "not in line with the previous tile"))
 		}
 		redTiles = append(redTiles, coords)
-		xCoordsSet[coords[0]] = true
-		yCoordsSet[coords[1]] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return redTiles, nil
+}
+
+// largestInsideRectangle returns the area of the largest rectangle with red
+// tiles at two opposite corners that lies wholly inside the loop they make.
+func largestInsideRectangle(redTiles [][2]int) int {
+	xCoordsSet := make(map[int]bool)
+	yCoordsSet := make(map[int]bool)
+	for _, t := range redTiles {
+		xCoordsSet[t[0]] = true
+		yCoordsSet[t[1]] = true
 	}
 
 	// 2. Coordinate Compression
@@ -83,7 +113,9 @@ func main() {
 		yMap[y] = i
 	}
 
-	// 3. Build pathTiles set and shapes map for all path points
+	// 3. Build pathTiles set and shapes map for all path points. Only points
+	// on the compressed grid are ever looked up, so segments are walked in
+	// compressed steps however long they are
 	pathTiles := make(map[[2]int]bool)
 	shapes := make(map[[2]int]rune) // Now this will contain shapes for all path points
 
@@ -93,16 +125,16 @@ func main() {
 
 		// Fill pathTiles and initial shapes for segments
 		if p1[0] == p2[0] { // Vertical segment
-			for y := min(p1[1], p2[1]); y <= max(p1[1], p2[1]); y++ {
-				tile := [2]int{p1[0], y}
+			for iy := yMap[min(p1[1], p2[1])]; iy <= yMap[max(p1[1], p2[1])]; iy++ {
+				tile := [2]int{p1[0], yCoords[iy]}
 				pathTiles[tile] = true
 				if _, exists := shapes[tile]; !exists { // Only set if not already determined as a corner
 					shapes[tile] = '|'
 				}
 			}
 		} else { // Horizontal segment
-			for x := min(p1[0], p2[0]); x <= max(p1[0], p2[0]); x++ {
-				tile := [2]int{x, p1[1]}
+			for ix := xMap[min(p1[0], p2[0])]; ix <= xMap[max(p1[0], p2[0])]; ix++ {
+				tile := [2]int{xCoords[ix], p1[1]}
 				pathTiles[tile] = true
 				if _, exists := shapes[tile]; !exists { // Only set if not already determined as a corner
 					shapes[tile] = '-'
@@ -258,5 +290,23 @@ func main() {
 		}
 	}
 
-	fmt.Println("Maximum rectangle area:", maxArea)
+	return maxArea
+}
+
+// parsePoint reads a line of two comma separated coordinates.
+func parsePoint(lineNum int, line string) ([2]int, error) {
+	var coords [2]int
+	strCoords := strings.Split(line, ",")
+	if len(strCoords) != 2 {
+		return coords, parse.Errorf(lineNum, line, "expected x,y")
+	}
+
+	for j, strCoord := range strCoords {
+		coord, err := parse.Int(lineNum, strCoord)
+		if err != nil {
+			return coords, err
+		}
+		coords[j] = coord
+	}
+	return coords, nil
 }
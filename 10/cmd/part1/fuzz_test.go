@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// maxFuzzButtons bounds the search, which can visit every combination of
+// buttons.
+const maxFuzzButtons = 16
+
+func FuzzParseMachines(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		machines, err := readMachines(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		for _, m := range machines {
+			if len(m.buttons) > maxFuzzButtons {
+				continue
+			}
+			if presses := m.Start(); presses > len(m.buttons) {
+				t.Errorf("%d presses with %d buttons, but pressing a button twice undoes it", presses, len(m.buttons))
+			}
+		}
+	})
+}
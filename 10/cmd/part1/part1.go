@@ -4,10 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"strconv"
 	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
 )
 
+// maxLights keeps every light addressable as a bit of an int mask.
+const maxLights = 62
+
 type machine struct {
 	reqMask  int
 	buttons  []int
@@ -48,84 +52,153 @@ func (m *machine) Start() int {
 func main() {
 	args := os.Args[1:]
 	if len(args) < 1 {
-		fmt.Println("Usage: go run part1.go <filename>")
-		return
+		fmt.Fprintln(os.Stderr, "Usage: go run part1.go <filename>")
+		os.Exit(1)
 	}
 
 	filename := args[0]
 	f, err := os.Open(filename)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
 	}
 	defer f.Close()
 
-	machines := make([]*machine, 0)
-	minPresses := 0
+	machines, err := readMachines(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
 
-	scanner := bufio.NewScanner(f)
-	for i := 0; scanner.Scan(); i++ {
-		line := scanner.Text()
-		parts := strings.Split(line, " ")
+	minPresses := 0
+	for _, machine := range machines {
+		minPresses += machine.Start()
+	}
 
-		machine := &machine{}
+	fmt.Println("Total minimum button presses for all machines:", minPresses)
+}
 
-		if len(parts) > 0 {
-			machine.reqMask = parseLights(parts[0])
+// readMachines reads one machine per line from scanner. Its errors say what
+// it was doing, like "parsing machine: ...".
+func readMachines(scanner *bufio.Scanner) ([]*machine, error) {
+	machines := make([]*machine, 0)
+	for i := 1; scanner.Scan(); i++ {
+		machine, err := parseMachine(i, scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("parsing machine: %w", err)
 		}
+		machines = append(machines, machine)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return machines, nil
+}
+
+// parseMachine reads a line like "[.##.] (3) (1,3) {3,5,4,7}": the required
+// lights, then the buttons, then optionally the joltages.
+func parseMachine(lineNum int, line string) (*machine, error) {
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return nil, parse.Errorf(lineNum, line, "empty machine")
+	}
+
+	m := &machine{}
+	reqMask, width, err := parseLights(lineNum, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	m.reqMask = reqMask
 
-		for i := 1; i < len(parts); i++ {
-			part := parts[i]
-			if strings.HasPrefix(part, "(") {
-				machine.buttons = append(machine.buttons, parseButtons(part))
-			} else if strings.HasPrefix(part, "{") {
-				machine.joltages = parseJoltages(part)
+	for _, part := range parts[1:] {
+		if strings.HasPrefix(part, "(") {
+			button, err := parseButtons(lineNum, part, width)
+			if err != nil {
+				return nil, err
 			}
+			m.buttons = append(m.buttons, button)
+		} else if strings.HasPrefix(part, "{") {
+			joltages, err := parseJoltages(lineNum, part, width)
+			if err != nil {
+				return nil, err
+			}
+			m.joltages = joltages
+		} else {
+			return nil, parse.Errorf(lineNum, part, "expected (buttons) or {joltages}")
 		}
-
-		machines = append(machines, machine)
-		minPresses += machine.Start()
 	}
 
-	fmt.Println("Total minimum button presses for all machines:", minPresses)
+	return m, nil
 }
 
-func parseLights(state string) int {
+// bracketed returns the contents of s between the given delimiters.
+func bracketed(lineNum int, s string, open, close byte) (string, error) {
+	if len(s) < 2 || s[0] != open || s[len(s)-1] != close {
+		return "", parse.Errorf(lineNum, s, "expected %c...%c", open, close)
+	}
+	return s[1 : len(s)-1], nil
+}
+
+// parseLights returns the required light mask and the number of lights.
+func parseLights(lineNum int, state string) (int, int, error) {
+	lightStr, err := bracketed(lineNum, state, '[', ']')
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(lightStr) == 0 || len(lightStr) > maxLights {
+		return 0, 0, parse.Errorf(lineNum, state, "need between 1 and %d lights", maxLights)
+	}
+
 	lights := 0
-	lightStr := state[1 : len(state)-1]
 	for i, ch := range lightStr {
 		if ch == '#' {
 			lights |= (1 << uint(i))
+		} else if ch != '.' {
+			return 0, 0, parse.Errorf(lineNum, state, "light %q is neither . nor #", ch)
 		}
 	}
-	return lights
+	return lights, len(lightStr), nil
 }
 
-func parseButtons(state string) int {
+func parseButtons(lineNum int, state string, width int) (int, error) {
+	inner, err := bracketed(lineNum, state, '(', ')')
+	if err != nil {
+		return 0, err
+	}
+
 	buttonMask := 0
-	nums := strings.Split(state[1:len(state)-1], ",")
-	for _, num := range nums {
-		val, err := strconv.Atoi(num)
+	for _, num := range strings.Split(inner, ",") {
+		val, err := parse.Int(lineNum, num)
 		if err != nil {
-			fmt.Println("Error parsing button value:", err)
-			continue
+			return 0, err
+		}
+		if val < 0 || val >= width {
+			return 0, parse.Errorf(lineNum, state, "button wired to light %d of %d", val, width)
 		}
 		buttonMask |= (1 << val)
 	}
-	return buttonMask
+	return buttonMask, nil
 }
 
-func parseJoltages(state string) []int {
+func parseJoltages(lineNum int, state string, width int) ([]int, error) {
+	inner, err := bracketed(lineNum, state, '{', '}')
+	if err != nil {
+		return nil, err
+	}
+
 	joltages := make([]int, 0)
-	nums := strings.Split(state[1:len(state)-1], ",")
-	for _, num := range nums {
-		val, err := strconv.Atoi(num)
+	for _, num := range strings.Split(inner, ",") {
+		val, err := parse.Int(lineNum, num)
 		if err != nil {
-			fmt.Println("Error parsing joltage value:", err)
-			continue
+			return nil, err
+		}
+		if val < 0 {
+			return nil, parse.Errorf(lineNum, state, "negative joltage")
 		}
 		joltages = append(joltages, val)
 	}
-	return joltages
+	if len(joltages) != width {
+		return nil, parse.Errorf(lineNum, state, "%d joltages for %d lights", len(joltages), width)
+	}
+	return joltages, nil
 }
-
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// Machines bigger than these take the solvers too long to fuzz.
+const (
+	maxFuzzButtons  = 8
+	maxFuzzCounters = 8
+	maxFuzzJoltage  = 20
+)
+
+func FuzzParseMachines(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	solverOrder = []string{"partition", "bnb", "dfs"}
+	partitionLimit, bnbLimit = 100*time.Millisecond, 100*time.Millisecond
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		machines, err := readMachines(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		for _, m := range machines {
+			if len(m.buttons) > maxFuzzButtons || len(m.joltages) > maxFuzzCounters ||
+				slices.Max(append([]int{0}, m.joltages...)) > maxFuzzJoltage {
+				continue
+			}
+			m.Start()
+		}
+	})
+}
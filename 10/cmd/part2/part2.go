@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"math/bits"
 	"os"
@@ -11,8 +12,23 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+// Solvers tried, in order, for joltage machines, and the time limits for the
+// ones that give up. Set from the command line.
+var (
+	solverOrder    = []string{"golp", "partition", "bnb", "dfs"}
+	partitionLimit = 28 * time.Second
+	bnbLimit       = 30 * time.Second
 )
 
+var knownSolvers = map[string]bool{"golp": true, "partition": true, "bnb": true, "z3": true, "dfs": true}
+
+// maxLights keeps every light addressable as a bit of an int mask.
+const maxLights = 62
+
 type machine struct {
 	reqMask  int
 	buttons  []int
@@ -21,8 +37,9 @@ type machine struct {
 
 // Try to find the shortest sequence of button presses that configures the machine.
 // If joltages are present, each button increments listed counters by 1 and counters start at 0.
-// Otherwise, fall back to the light-toggle XOR model.
-func (m *machine) Start() int {
+// Otherwise, fall back to the light-toggle XOR model. It fails when no presses
+// configure the machine or every solver tried gives up.
+func (m *machine) Start() (int, error) {
 	// Joltages mode: counters with non-negative integer presses; use DFS on button press counts with pruning.
 	if len(m.joltages) > 0 {
 		target := m.joltages
@@ -39,7 +56,7 @@ func (m *machine) Start() int {
 		}
 		for i := 0; i < d; i++ {
 			if target[i] > 0 && !covered[i] {
-				return -1
+				return 0, fmt.Errorf("no button reaches counter %d", i)
 			}
 		}
 
@@ -165,29 +182,39 @@ func (m *machine) Start() int {
 			return bestLocal
 		}
 
-		// Try GLPK via golp package
-		if v, ok := solveGolp(masks, target); ok {
-			return v
-		}
-		// Try partition-DFS solver inspired by Reddit (choose counter with fewest buttons, iterate partitions)
-		if v, ok := solvePartitionDFS(masks, target); ok {
-			return v
-		}
-		// Try branch-and-bound native solver first
-		if v, ok := solveBnB(masks, target); ok {
-			return v
-		}
-		// fallback to native solver
-		res := solve(0, rem)
-		if res == INF {
-			return -1
+		for _, name := range solverOrder {
+			switch name {
+			case "golp":
+				// GLPK via the golp package, only available with -tags golp
+				if v, ok := solveGolp(masks, target); ok {
+					return v, nil
+				}
+			case "partition":
+				// Partition-DFS solver inspired by Reddit (choose counter with fewest buttons, iterate partitions)
+				if v, ok := solvePartitionDFS(masks, target); ok {
+					return v, nil
+				}
+			case "bnb":
+				if v, ok := solveBnB(masks, target); ok {
+					return v, nil
+				}
+			case "z3":
+				if v, ok := solveZ3(masks, target); ok {
+					return v, nil
+				}
+			case "dfs":
+				// Native memoized DFS, no time limit
+				if res := solve(0, rem); res != INF {
+					return res, nil
+				}
+			}
 		}
-		return res
+		return 0, fmt.Errorf("every solver gave up (tried %s)", strings.Join(solverOrder, ", "))
 	}
 
 	// Lights/toggle mode (bitmask BFS)
 	if m.reqMask == 0 {
-		return 0
+		return 0, nil
 	}
 
 	level := []int{0}
@@ -201,7 +228,7 @@ func (m *machine) Start() int {
 			for _, button := range m.buttons {
 				nextMask := mask ^ button
 				if nextMask == m.reqMask {
-					return presses
+					return presses, nil
 				}
 				if !visited[nextMask] {
 					visited[nextMask] = true
@@ -211,91 +238,179 @@ func (m *machine) Start() int {
 		}
 		level = nextLevel
 	}
-	return -1
+	return 0, fmt.Errorf("no presses light the lights")
 }
 
 func main() {
-	args := os.Args[1:]
+	solvers := flag.String("solvers", strings.Join(solverOrder, ","), "comma separated solvers to try in order: golp, partition, bnb, z3, dfs")
+	flag.DurationVar(&partitionLimit, "limit", partitionLimit, "time limit for the partition solver")
+	flag.DurationVar(&bnbLimit, "bnb-limit", bnbLimit, "time limit for the branch-and-bound solver")
+	flag.Parse()
+
+	solverOrder = strings.Split(*solvers, ",")
+	for _, name := range solverOrder {
+		if !knownSolvers[name] {
+			fmt.Fprintln(os.Stderr, "Unknown solver:", name)
+			os.Exit(1)
+		}
+	}
+
+	args := flag.Args()
 	if len(args) < 1 {
-		fmt.Println("Usage: go run part1.go <filename>")
-		return
+		fmt.Fprintln(os.Stderr, "Usage: go run part1.go <filename>")
+		os.Exit(1)
 	}
 
 	filename := args[0]
 	f, err := os.Open(filename)
 	if err != nil {
-		fmt.Println("Error opening file:", err)
-		return
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
 	}
 	defer f.Close()
 
-	machines := make([]*machine, 0)
-	minPresses := 0
+	machines, err := readMachines(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
 
-	scanner := bufio.NewScanner(f)
-	for i := 0; scanner.Scan(); i++ {
-		line := scanner.Text()
-		parts := strings.Split(line, " ")
+	minPresses := 0
+	for i, machine := range machines {
+		presses, err := machine.Start()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error solving machine %d: %v\n", i+1, err)
+			os.Exit(1)
+		}
+		minPresses += presses
+	}
 
-		machine := &machine{}
+	fmt.Println("Total minimum button presses for all machines:", minPresses)
+}
 
-		if len(parts) > 0 {
-			machine.reqMask = parseLights(parts[0])
+// readMachines reads one machine per line from scanner. Its errors say what
+// it was doing, like "parsing machine: ...".
+func readMachines(scanner *bufio.Scanner) ([]*machine, error) {
+	machines := make([]*machine, 0)
+	for i := 1; scanner.Scan(); i++ {
+		machine, err := parseMachine(i, scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("parsing machine: %w", err)
 		}
+		machines = append(machines, machine)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	return machines, nil
+}
+
+// parseMachine reads a line like "[.##.] (3) (1,3) {3,5,4,7}": the required
+// lights, then the buttons, then optionally the joltages.
+func parseMachine(lineNum int, line string) (*machine, error) {
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return nil, parse.Errorf(lineNum, line, "empty machine")
+	}
+
+	m := &machine{}
+	reqMask, width, err := parseLights(lineNum, parts[0])
+	if err != nil {
+		return nil, err
+	}
+	m.reqMask = reqMask
 
-		for i := 1; i < len(parts); i++ {
-			part := parts[i]
-			if strings.HasPrefix(part, "(") {
-				machine.buttons = append(machine.buttons, parseButtons(part))
-			} else if strings.HasPrefix(part, "{") {
-				machine.joltages = parseJoltages(part)
+	for _, part := range parts[1:] {
+		if strings.HasPrefix(part, "(") {
+			button, err := parseButtons(lineNum, part, width)
+			if err != nil {
+				return nil, err
+			}
+			m.buttons = append(m.buttons, button)
+		} else if strings.HasPrefix(part, "{") {
+			joltages, err := parseJoltages(lineNum, part, width)
+			if err != nil {
+				return nil, err
 			}
+			m.joltages = joltages
+		} else {
+			return nil, parse.Errorf(lineNum, part, "expected (buttons) or {joltages}")
 		}
-
-		machines = append(machines, machine)
-		minPresses += machine.Start()
 	}
 
-	fmt.Println("Total minimum button presses for all machines:", minPresses)
+	return m, nil
+}
+
+// bracketed returns the contents of s between the given delimiters.
+func bracketed(lineNum int, s string, open, close byte) (string, error) {
+	if len(s) < 2 || s[0] != open || s[len(s)-1] != close {
+		return "", parse.Errorf(lineNum, s, "expected %c...%c", open, close)
+	}
+	return s[1 : len(s)-1], nil
 }
 
-func parseLights(state string) int {
+// parseLights returns the required light mask and the number of lights.
+func parseLights(lineNum int, state string) (int, int, error) {
+	lightStr, err := bracketed(lineNum, state, '[', ']')
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(lightStr) == 0 || len(lightStr) > maxLights {
+		return 0, 0, parse.Errorf(lineNum, state, "need between 1 and %d lights", maxLights)
+	}
+
 	lights := 0
-	lightStr := state[1 : len(state)-1]
 	for i, ch := range lightStr {
 		if ch == '#' {
 			lights |= (1 << uint(i))
+		} else if ch != '.' {
+			return 0, 0, parse.Errorf(lineNum, state, "light %q is neither . nor #", ch)
 		}
 	}
-	return lights
+	return lights, len(lightStr), nil
 }
 
-func parseButtons(state string) int {
+func parseButtons(lineNum int, state string, width int) (int, error) {
+	inner, err := bracketed(lineNum, state, '(', ')')
+	if err != nil {
+		return 0, err
+	}
+
 	buttonMask := 0
-	nums := strings.Split(state[1:len(state)-1], ",")
-	for _, num := range nums {
-		val, err := strconv.Atoi(num)
+	for _, num := range strings.Split(inner, ",") {
+		val, err := parse.Int(lineNum, num)
 		if err != nil {
-			fmt.Println("Error parsing button value:", err)
-			continue
+			return 0, err
+		}
+		if val < 0 || val >= width {
+			return 0, parse.Errorf(lineNum, state, "button wired to light %d of %d", val, width)
 		}
 		buttonMask |= (1 << val)
 	}
-	return buttonMask
+	return buttonMask, nil
 }
 
-func parseJoltages(state string) []int {
+func parseJoltages(lineNum int, state string, width int) ([]int, error) {
+	inner, err := bracketed(lineNum, state, '{', '}')
+	if err != nil {
+		return nil, err
+	}
+
 	joltages := make([]int, 0)
-	nums := strings.Split(state[1:len(state)-1], ",")
-	for _, num := range nums {
-		val, err := strconv.Atoi(num)
+	for _, num := range strings.Split(inner, ",") {
+		val, err := parse.Int(lineNum, num)
 		if err != nil {
-			fmt.Println("Error parsing joltage value:", err)
-			continue
+			return nil, err
+		}
+		if val < 0 {
+			return nil, parse.Errorf(lineNum, state, "negative joltage")
 		}
 		joltages = append(joltages, val)
 	}
-	return joltages
+	if len(joltages) != width {
+		return nil, parse.Errorf(lineNum, state, "%d joltages for %d lights", len(joltages), width)
+	}
+	return joltages, nil
 }
 
 // solveBnB implements a branch-and-bound native solver for the integer system A x = target.
@@ -322,7 +437,7 @@ func solveBnB(masks []int, target []int) (int, bool) {
 	}
 
 	start := time.Now()
-	limit := 30 * time.Second
+	limit := bnbLimit
 	// greedy upper bound
 	rem := make([]int, d)
 	copy(rem, target)
@@ -528,7 +643,7 @@ func solvePartitionDFS(masks []int, target []int) (int, bool) {
 	memo := map[int]int{}
 	// timeout to avoid excessive runtimes
 	start := time.Now()
-	limit := 28 * time.Second
+	limit := partitionLimit
 
 	var dfs func(rem []int) int
 	dfs = func(rem []int) int {
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+func FuzzParseDevices(f *testing.F) {
+	example, err := os.ReadFile("../../testdata/example1.txt")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(example)
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		nodes, err := readDevices(bufio.NewScanner(bytes.NewReader(input)))
+		if err != nil {
+			if !errors.As(err, new(*parse.Error)) && !errors.Is(err, bufio.ErrTooLong) {
+				t.Fatalf("error is not a *parse.Error: %v", err)
+			}
+			return
+		}
+
+		// main rejects cycles before counting paths
+		if findCycle(nodes) != "" {
+			return
+		}
+		if you := nodes["you"]; you != nil {
+			getPathsOut(you, nodes)
+		}
+	})
+}
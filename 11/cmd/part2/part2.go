@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+type node struct {
+	name     string
+	children []string
+}
+
+func main() {
+	args := os.Args[1:]
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	nodes, err := readDevices(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	if name := findCycle(nodes); name != "" {
+		fmt.Fprintln(os.Stderr, "Error: Device", name, "feeds back into itself.")
+		os.Exit(1)
+	}
+
+	n := getPathsOut(nodes)
+	fmt.Println("Total paths from 'svr' to 'out' including 'fft' and 'dac':", n)
+}
+
+// readDevices reads the devices and their outputs from scanner, adding the
+// "out" device every path ends at. Its errors say what it was doing, like
+// "parsing device: ...".
+func readDevices(scanner *bufio.Scanner) (map[string]*node, error) {
+	nodes := make(map[string]*node)
+	for i := 0; scanner.Scan(); i++ {
+		line := scanner.Text()
+
+		segments := strings.Fields(line)
+		if len(segments) == 0 || len(segments[0]) < 2 || !strings.HasSuffix(segments[0], ":") {
+			return nil, fmt.Errorf("parsing device: %w", parse.Errorf(i+1, line, "expected a line like \"aaa: bbb ccc\""))
+		}
+		nodeName := getNodeName(segments[0])
+
+		if _, ok := nodes[nodeName]; !ok {
+			nodes[nodeName] = &node{
+				name:     nodeName,
+				children: segments[1:],
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	nodes["out"] = &node{
+		name:     "out",
+		children: []string{},
+	}
+
+	return nodes, nil
+}
+
+// findCycle returns the name of a node that can reach itself, or "" if the
+// graph is acyclic. Path counting recurses forever on a cycle.
+func findCycle(nodes map[string]*node) string {
+	const (
+		unvisited = iota
+		onPath
+		done
+	)
+	state := make(map[string]int)
+
+	var visit func(n *node) string
+	visit = func(n *node) string {
+		switch state[n.name] {
+		case onPath:
+			return n.name
+		case done:
+			return ""
+		}
+
+		state[n.name] = onPath
+		for _, childName := range n.children {
+			if child, ok := nodes[childName]; ok {
+				if name := visit(child); name != "" {
+					return name
+				}
+			}
+		}
+		state[n.name] = done
+		return ""
+	}
+
+	for _, n := range nodes {
+		if name := visit(n); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func getNodeName(s string) string {
+	return s[:len(s)-1] // Remove the colon at the end
+}
+
+func getPathsOut(nodes map[string]*node) int {
+	svr := nodes["svr"]
+	if svr == nil {
+		return 0
+	}
+
+	totalPaths := 0
+
+	memoToFft := make(map[string]int)
+	memoToDac := make(map[string]int)
+	memoToOut := make(map[string]int)
+
+	svrToFft := getTo(svr, "fft", nodes, memoToFft)
+	fftToDac := getTo(nodes["fft"], "dac", nodes, memoToDac)
+	dacToOut := getTo(nodes["dac"], "out", nodes, memoToOut)
+
+	svrToDac := getTo(svr, "dac", nodes, memoToDac)
+	dacToFft := getTo(nodes["dac"], "fft", nodes, memoToFft)
+	fftToOut := getTo(nodes["fft"], "out", nodes, memoToOut)
+
+	totalPaths += svrToFft * fftToDac * dacToOut
+	totalPaths += svrToDac * dacToFft * fftToOut
+
+	return totalPaths
+}
+
+func getTo(n *node, target string, nodes map[string]*node, memo map[string]int) int {
+	if n == nil { // fft or dac missing from the input
+		return 0
+	}
+
+	if n.name == target {
+		return 1
+	}
+
+	if count, ok := memo[n.name]; ok {
+		return count
+	}
+
+	if len(n.children) == 0 {
+		memo[n.name] = 0
+		return 0
+	}
+
+	childrenLeadingOut := 0
+	for _, childName := range n.children {
+		childNode, ok := nodes[childName]
+		if !ok {
+			continue
+		}
+
+		childrenLeadingOut += getTo(childNode, target, nodes, memo)
+	}
+
+	memo[n.name] = childrenLeadingOut
+	return childrenLeadingOut
+}
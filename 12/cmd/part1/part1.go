@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/parse"
+)
+
+type shape struct {
+	area int
+}
+
+type grid struct {
+	width  int
+	height int
+	area   int
+
+	shapeCount []int
+}
+
+func main() {
+	args := os.Args[1:]
+	if len(args) < 1 {
+		fmt.Fprintln(os.Stderr, "Please provide an input filename.")
+		os.Exit(1)
+	}
+
+	filename := args[0]
+	f, err := os.Open(filename)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error opening file:", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	shapes, grids, err := readPuzzle(bufio.NewScanner(f))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Total number of grids that can fit all shapes:", countFitting(shapes, grids))
+}
+
+// readPuzzle reads the numbered shapes and then the regions from scanner.
+// Its errors say what it was doing, like "parsing region: ...".
+func readPuzzle(scanner *bufio.Scanner) ([]*shape, []*grid, error) {
+	shapes := make([]*shape, 0)
+	grids := make([]*grid, 0)
+	idx := -1
+
+	for i := 1; scanner.Scan(); i++ {
+		line := scanner.Text()
+
+		if cidx := strings.Index(line, ":"); cidx != -1 {
+			if !strings.Contains(line[:cidx], "x") {
+				id, err := parse.Int(i, strings.TrimSpace(line[:cidx]))
+				if err != nil {
+					return nil, nil, fmt.Errorf("parsing shape: %w", err)
+				}
+				if id != len(shapes) {
+					return nil, nil, fmt.Errorf("parsing shape: %w", parse.Errorf(i, line, "expected shape %d next", len(shapes)))
+				}
+				idx = id
+				shapes = append(shapes, &shape{})
+				continue
+			}
+
+			g, err := parseGrid(i, line, cidx, len(shapes))
+			if err != nil {
+				return nil, nil, fmt.Errorf("parsing region: %w", err)
+			}
+			grids = append(grids, g)
+			continue
+		}
+
+		for _, c := range line {
+			if c != '#' && c != '.' {
+				return nil, nil, fmt.Errorf("parsing shape: %w", parse.Errorf(i, line, "unexpected %q in shape", c))
+			}
+			if c == '#' {
+				if idx == -1 {
+					return nil, nil, fmt.Errorf("parsing shape: %w", parse.Errorf(i, line, "shape rows before any shape header"))
+				}
+				shapes[idx].area++
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("reading file: %w", err)
+	}
+
+	return shapes, grids, nil
+}
+
+// countFitting counts the regions with room for the total area of the shapes
+// they are to hold.
+func countFitting(shapes []*shape, grids []*grid) int {
+	canFitAllCount := 0
+
+	for _, g := range grids {
+		totalShapesArea := 0
+		for i, count := range g.shapeCount {
+			totalShapesArea += count * shapes[i].area
+		}
+
+		if totalShapesArea <= g.area {
+			canFitAllCount++
+		}
+	}
+
+	return canFitAllCount
+}
+
+// parseGrid reads a region line like "12x5: 1 0 1 0 2 2", where cidx is the
+// position of the colon and shapeCount the number of shapes defined so far.
+func parseGrid(lineNum int, line string, cidx int, shapeCount int) (*grid, error) {
+	sizes := strings.Split(line[:cidx], "x")
+	if len(sizes) != 2 {
+		return nil, parse.Errorf(lineNum, line, "expected a size like 12x5")
+	}
+
+	w, err := parse.Int(lineNum, sizes[0])
+	if err != nil {
+		return nil, err
+	}
+	h, err := parse.Int(lineNum, sizes[1])
+	if err != nil {
+		return nil, err
+	}
+	if w < 0 || h < 0 {
+		return nil, parse.Errorf(lineNum, line, "negative region size")
+	}
+
+	rawShapeCounts := strings.Fields(strings.TrimSpace(line[cidx+1:]))
+	if len(rawShapeCounts) > shapeCount {
+		return nil, parse.Errorf(lineNum, line, "counts for %d shapes, but only %d defined", len(rawShapeCounts), shapeCount)
+	}
+	shapeCounts := make([]int, len(rawShapeCounts))
+	for j, c := range rawShapeCounts {
+		count, err := parse.Int(lineNum, c)
+		if err != nil {
+			return nil, err
+		}
+		if count < 0 {
+			return nil, parse.Errorf(lineNum, line, "negative shape count")
+		}
+		shapeCounts[j] = count
+	}
+
+	return &grid{
+		width:      w,
+		height:     h,
+		area:       w * h,
+		shapeCount: shapeCounts,
+	}, nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// answerExt marks the sidecar file holding the expected answer for an input:
+// either input.txt.answer or input.answer next to input.txt.
+const answerExt = ".answer"
+
+// result is the outcome of running a solution against one input.
+type result struct {
+	file     string
+	answer   string
+	elapsed  time.Duration
+	err      error
+	expected string // empty when there is no sidecar or checking is off
+}
+
+// runBatch builds the solution once and runs it against every input in dir,
+// at most jobs at a time, then prints a table of the results.
+func runBatch(s *solution, passthrough []string, dir string, jobs int, check bool) error {
+	inputs, err := listInputs(dir)
+	if err != nil {
+		return err
+	}
+	if len(inputs) == 0 {
+		return fmt.Errorf("no inputs in %s", dir)
+	}
+
+	tmp, err := os.MkdirTemp("", "aoc-batch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	bin, err := s.build(tmp)
+	if err != nil {
+		return err
+	}
+
+	results := make([]result, len(inputs))
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(jobs, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				results[i] = runOne(bin, s.args(passthrough, inputs[i]))
+				results[i].file = inputs[i]
+				if check {
+					results[i].expected = readAnswer(inputs[i])
+				}
+			}
+		}()
+	}
+	for i := range inputs {
+		work <- i
+	}
+	close(work)
+	wg.Wait()
+
+	return printResults(results, check)
+}
+
+// build compiles the solution into dir and returns the binary's path, so it
+// can be run many times without paying for go run each time.
+func (s *solution) build(dir string) (string, error) {
+	bin := filepath.Join(dir, fmt.Sprintf("day%02d-part%d", s.day, s.part))
+	cmd := exec.Command("go", "build", "-o", bin, s.pkg)
+	cmd.Dir = s.dir
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("building day %d part %d: %w", s.day, s.part, err)
+	}
+	return bin, nil
+}
+
+// listInputs returns the input files in dir, skipping answer sidecars,
+// hidden files and subdirectories.
+func listInputs(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]string, 0, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if !e.Type().IsRegular() || strings.HasPrefix(name, ".") || strings.HasSuffix(name, answerExt) {
+			continue
+		}
+		path, err := filepath.Abs(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, path)
+	}
+	sort.Strings(inputs)
+
+	return inputs, nil
+}
+
+func runOne(bin string, args []string) result {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(bin, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	r := result{elapsed: time.Since(start), answer: answerOf(stdout.String())}
+	if err != nil {
+		if msg := lastLine(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		r.err = err
+	}
+
+	return r
+}
+
+// answerOf extracts the answer from a solution's output. Every solution ends
+// by printing a line like "Total sum: 1234", so it is the last field of the
+// last line.
+func answerOf(output string) string {
+	fields := strings.Fields(lastLine(output))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// readAnswer returns the expected answer for input, or "" without a sidecar.
+func readAnswer(input string) string {
+	candidates := []string{
+		input + answerExt,
+		strings.TrimSuffix(input, filepath.Ext(input)) + answerExt,
+	}
+	for _, c := range candidates {
+		if data, err := os.ReadFile(c); err == nil {
+			return strings.TrimSpace(string(data))
+		}
+	}
+	return ""
+}
+
+// printResults writes the results table and reports whether any run failed or
+// disagreed with its sidecar.
+func printResults(results []result, check bool) error {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := "FILE\tANSWER\tTIME"
+	if check {
+		header += "\tCHECK"
+	}
+	fmt.Fprintln(tw, header)
+
+	failed := 0
+	for _, r := range results {
+		name := filepath.Base(r.file)
+		answer := r.answer
+		if r.err != nil {
+			answer = "error: " + r.err.Error()
+			failed++
+		}
+		row := fmt.Sprintf("%s\t%s\t%s", name, answer, r.elapsed.Round(time.Millisecond))
+
+		if check {
+			switch {
+			case r.err != nil:
+				row += "\t-"
+			case r.expected == "":
+				row += "\tno answer file"
+			case r.expected == r.answer:
+				row += "\tok"
+			default:
+				row += "\tWRONG (want " + r.expected + ")"
+				failed++
+			}
+		}
+		fmt.Fprintln(tw, row)
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d inputs failed", failed, len(results))
+	}
+	return nil
+}
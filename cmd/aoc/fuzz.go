@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// fuzzTarget is one native fuzz test and the package it lives in.
+type fuzzTarget struct {
+	pkg  string
+	name string
+}
+
+// fuzzCmd runs each of a day's FuzzParse targets in turn with go test -fuzz.
+// The fuzzing itself, minimizing included, is left to the go tool, which
+// saves any failing input under the package's testdata/fuzz so that plain
+// go test replays it from then on.
+func fuzzCmd(args []string) error {
+	fs := flag.NewFlagSet("fuzz", flag.ExitOnError)
+	fuzzTime := fs.Duration("fuzztime", 30*time.Second, "how long to fuzz each target")
+	run := fs.String("run", "", "only fuzz targets whose name contains this")
+
+	rest := parseInterspersed(fs, args)
+	if len(rest) != 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	day, err := strconv.Atoi(rest[0])
+	if err != nil || day < 1 || day > 25 {
+		return fmt.Errorf("invalid day %q", rest[0])
+	}
+
+	root, err := findRoot()
+	if err != nil {
+		return err
+	}
+
+	// Like locate, run a day with its own go.mod from inside it.
+	dayDir := fmt.Sprintf("%02d", day)
+	if _, err := os.Stat(filepath.Join(root, dayDir)); err != nil {
+		return fmt.Errorf("no solutions for day %d", day)
+	}
+	dir, pattern := root, "./"+dayDir+"/..."
+	if _, err := os.Stat(filepath.Join(root, dayDir, "go.mod")); err == nil {
+		dir, pattern = filepath.Join(root, dayDir), "./..."
+	}
+
+	targets, err := listFuzzTargets(dir, pattern)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no fuzz targets for day %d", day)
+	}
+
+	for _, t := range targets {
+		if !strings.Contains(t.name, *run) {
+			continue
+		}
+
+		fmt.Printf("Fuzzing %s in %s for %s\n", t.name, t.pkg, *fuzzTime)
+		cmd := exec.Command("go", "test", "-run", "^$", "-fuzz", "^"+t.name+"$", "-fuzztime", fuzzTime.String(), t.pkg)
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listFuzzTargets asks go test for the fuzz targets in the packages matching
+// pattern. It prints each package's test names followed by an "ok" line
+// naming the package.
+func listFuzzTargets(dir, pattern string) ([]fuzzTarget, error) {
+	var stderr bytes.Buffer
+	cmd := exec.Command("go", "test", "-list", "^Fuzz", pattern)
+	cmd.Dir = dir
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		// Report go test's complaint rather than just its exit status
+		return nil, fmt.Errorf("listing fuzz targets: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	var targets []fuzzTarget
+	var names []string
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 0:
+		case fields[0] == "ok" && len(fields) > 1:
+			for _, name := range names {
+				targets = append(targets, fuzzTarget{pkg: fields[1], name: name})
+			}
+			names = nil
+		case strings.HasPrefix(fields[0], "Fuzz"):
+			names = append(names, fields[0])
+		}
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,311 @@
+// Command aoc runs puzzle solutions with their parameters taken from the
+// project configuration file.
+//
+//	aoc run [flags] <day> <part> [input] [-- solution flags]
+//	aoc run [flags] <day> <part> -inputs <dir> [-check] [-- solution flags]
+//	aoc fuzz [flags] <day>
+//	aoc query [flags] 1 [position <i> | landings <i> <j> | passes <i> <j>]
+//	aoc query [flags] 2 [next <n> | prev <n> | kth <k> | count <a> <b>]
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/internal/config"
+)
+
+const modulePath = "github.com/dfryer1193/AoC-2025"
+
+// defaultInput is where a day's input is looked for when neither the command
+// line nor the configuration say otherwise. {day} becomes the two digit day.
+const defaultInput = "{day}/input.txt"
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCmd(os.Args[2:])
+	case "fuzz":
+		err = fuzzCmd(os.Args[2:])
+	case "query":
+		err = queryCmd(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		fmt.Fprintln(os.Stderr, "aoc:", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: aoc run [flags] <day> <part> [input] [-- solution flags]")
+	fmt.Fprintln(os.Stderr, "       aoc run [flags] <day> <part> -inputs <dir> [-check] [-- solution flags]")
+	fmt.Fprintln(os.Stderr, "       aoc fuzz [flags] <day>")
+	fmt.Fprintln(os.Stderr, "       aoc query [flags] 1 [position <i> | landings <i> <j> | passes <i> <j>]")
+	fmt.Fprintln(os.Stderr, "       aoc query [flags] 2 [next <n> | prev <n> | kth <k> | count <a> <b>]")
+}
+
+// params collects repeated -set key=value flags.
+type params map[string]string
+
+func (p params) String() string {
+	return fmt.Sprint(map[string]string(p))
+}
+
+func (p params) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("expected key=value, got %q", s)
+	}
+	p[k] = v
+	return nil
+}
+
+func runCmd(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	configPath := fs.String("config", os.Getenv("AOC_CONFIG"), "configuration file (default aoc.toml or aoc.yaml in the repository root)")
+	input := fs.String("input", "", "input file (default from config, then "+defaultInput+")")
+	inputsDir := fs.String("inputs", "", "run against every file in this directory instead of a single input")
+	check := fs.Bool("check", false, "with -inputs, compare each answer to the file's .answer sidecar")
+	jobs := fs.Int("jobs", runtime.NumCPU(), "with -inputs, how many inputs to run at once")
+	memBudget := fs.String("mem-budget", "", "memory budget for the solution, such as 64MiB (default from config, else unlimited)")
+	overrides := params{}
+	fs.Var(overrides, "set", "override a puzzle parameter, as key=value (repeatable)")
+
+	args, passthrough := splitPassthrough(args)
+	rest := parseInterspersed(fs, args)
+	if len(rest) < 2 || len(rest) > 3 || (len(rest) == 3 && *inputsDir != "") {
+		usage()
+		os.Exit(2)
+	}
+
+	s, cfg, err := prepare(rest[0], rest[1], *configPath, overrides)
+	if err != nil {
+		return err
+	}
+	setBudget(cfg, *memBudget)
+
+	if *inputsDir != "" {
+		return runBatch(s, passthrough, *inputsDir, *jobs, *check)
+	}
+
+	if len(rest) == 3 {
+		*input = rest[2]
+	}
+	inputPath, err := resolveInput(s.root, cfg, s.day, *input)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("go", append([]string{"run", s.pkg}, s.args(passthrough, inputPath)...)...)
+	cmd.Dir = s.dir
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// prepare locates the solution for a day and part and resolves its
+// parameters from the configuration and the -set overrides.
+func prepare(dayArg, partArg, configPath string, overrides params) (*solution, *config.Config, error) {
+	day, part, err := parseDayPart(dayArg, partArg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root, err := findRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	cfg, err := loadConfig(root, configPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s, err := locate(root, day, part)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.params = cfg.Params(day, part)
+	for k, v := range overrides {
+		s.params[k] = v
+	}
+
+	return s, cfg, nil
+}
+
+// setBudget passes the memory budget from the command line or the global
+// mem-budget setting on to solutions, which read it from $AOC_MEM_BUDGET.
+// Streaming solutions stay within it; whole-input ones refuse inputs that
+// cannot fit.
+func setBudget(cfg *config.Config, flagValue string) {
+	budget := flagValue
+	if budget == "" {
+		budget, _ = cfg.Global("mem-budget")
+	}
+	if budget != "" {
+		os.Setenv("AOC_MEM_BUDGET", budget)
+	}
+}
+
+// splitPassthrough separates the runner's own arguments from the ones after
+// "--", which go to the solution untouched.
+func splitPassthrough(args []string) ([]string, []string) {
+	for i, a := range args {
+		if a == "--" {
+			return args[:i], args[i+1:]
+		}
+	}
+	return args, nil
+}
+
+// parseInterspersed parses fs allowing flags after positional arguments, so
+// both "run -inputs d 4 2" and "run 4 2 -inputs d" work. It returns the
+// positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			return positional
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func parseDayPart(dayArg, partArg string) (int, int, error) {
+	day, err := strconv.Atoi(dayArg)
+	if err != nil || day < 1 || day > 25 {
+		return 0, 0, fmt.Errorf("invalid day %q", dayArg)
+	}
+	part, err := strconv.Atoi(partArg)
+	if err != nil || part < 1 || part > 2 {
+		return 0, 0, fmt.Errorf("invalid part %q", partArg)
+	}
+	return day, part, nil
+}
+
+// solution is one runnable puzzle part.
+type solution struct {
+	day, part int
+	root      string            // repository root
+	dir       string            // module directory to run from
+	pkg       string            // package path relative to dir
+	params    map[string]string // puzzle parameters, passed as flags
+}
+
+// locate finds the command for a day's part. Most days live in the root
+// module; a day with its own go.mod (like day 10) is run from inside it.
+func locate(root string, day, part int) (*solution, error) {
+	dayDir := fmt.Sprintf("%02d", day)
+	s := &solution{day: day, part: part, root: root, dir: root, pkg: fmt.Sprintf("./%s/cmd/part%d", dayDir, part)}
+	if _, err := os.Stat(filepath.Join(root, dayDir, "go.mod")); err == nil {
+		s.dir = filepath.Join(root, dayDir)
+		s.pkg = fmt.Sprintf("./cmd/part%d", part)
+	}
+
+	if _, err := os.Stat(filepath.Join(s.dir, s.pkg)); err != nil {
+		return nil, fmt.Errorf("no solution for day %d part %d", day, part)
+	}
+	return s, nil
+}
+
+// args builds the solution's command line. Parameters become -key=value
+// flags ahead of any passthrough flags, so the latter win.
+func (s *solution) args(passthrough []string, input string) []string {
+	var args []string
+	for _, k := range config.Keys(s.params) {
+		args = append(args, fmt.Sprintf("-%s=%s", k, s.params[k]))
+	}
+	args = append(args, passthrough...)
+	return append(args, input)
+}
+
+func loadConfig(root, path string) (*config.Config, error) {
+	if path == "" {
+		var ok bool
+		if path, ok = config.Find(root); !ok {
+			return config.Empty(), nil
+		}
+	}
+	return config.Load(path)
+}
+
+// resolveInput picks the input file: the command line first, then the global
+// input setting, then defaultInput. Relative paths from the configuration are
+// relative to the repository root; those given on the command line to the
+// working directory.
+func resolveInput(root string, cfg *config.Config, day int, flagValue string) (string, error) {
+	path := flagValue
+	if path == "" {
+		tmpl, ok := cfg.Global("input")
+		if !ok {
+			tmpl = defaultInput
+		}
+		path = strings.ReplaceAll(tmpl, "{day}", fmt.Sprintf("%02d", day))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(root, path)
+		}
+	}
+
+	return filepath.Abs(path)
+}
+
+// findRoot walks up from the working directory to the repository's root module.
+func findRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		if isRootModule(filepath.Join(dir, "go.mod")) {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not inside the " + modulePath + " repository")
+		}
+		dir = parent
+	}
+}
+
+func isRootModule(goMod string) bool {
+	f, err := os.Open(goMod)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if mod, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.TrimSpace(mod) == modulePath
+		}
+	}
+	return false
+}
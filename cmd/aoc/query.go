@@ -0,0 +1,247 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dfryer1193/AoC-2025/01/dial"
+	"github.com/dfryer1193/AoC-2025/02/ids"
+)
+
+// queryCmd answers questions about a day's run without re-running it with
+// prints enabled. Day 1 keeps a history to ask about:
+//
+//	position <i>       where the dial was after instruction i (0 is the start)
+//	landings <i> <j>   rotations among i..j that stopped on the target
+//	passes <i> <j>     times rotations i..j moved through the target
+//
+// Day 2 asks about the invalid IDs under the part's rule and base, needing
+// no input at all:
+//
+//	next <n>           the first invalid ID at or after n
+//	prev <n>           the last invalid ID before n
+//	kth <k>            the k-th invalid ID, counting from 1
+//	count <a> <b>      invalid IDs in a..b
+//
+// A query can be given on the command line; otherwise queries are read one
+// per line from stdin.
+func queryCmd(args []string) error {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	configPath := fs.String("config", os.Getenv("AOC_CONFIG"), "configuration file (default aoc.toml or aoc.yaml in the repository root)")
+	input := fs.String("input", "", "input file (default from config, then "+defaultInput+")")
+	part := fs.Int("part", 1, "take dial parameters from this part's configuration")
+	overrides := params{}
+	fs.Var(overrides, "set", "override a puzzle parameter, as key=value (repeatable)")
+
+	rest := parseInterspersed(fs, args)
+	if len(rest) < 1 {
+		usage()
+		os.Exit(2)
+	}
+	if rest[0] != "1" && rest[0] != "2" {
+		return fmt.Errorf("queries are only available for days 1 and 2")
+	}
+
+	s, cfg, err := prepare(rest[0], strconv.Itoa(*part), *configPath, overrides)
+	if err != nil {
+		return err
+	}
+
+	var query func(fields []string) (string, error)
+	if s.day == 2 {
+		query, err = idQueries(s.params, *part)
+		if err != nil {
+			return err
+		}
+	} else {
+		inputPath, err := resolveInput(s.root, cfg, s.day, *input)
+		if err != nil {
+			return err
+		}
+		h, err := dialHistory(s.params, inputPath)
+		if err != nil {
+			return err
+		}
+		query = func(fields []string) (string, error) { return dialQuery(h, fields) }
+	}
+
+	if len(rest) > 1 {
+		answer, err := query(rest[1:])
+		if err != nil {
+			return err
+		}
+		fmt.Println(answer)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		answer, err := query(fields)
+		if err != nil {
+			fmt.Println("Error:", err)
+			continue
+		}
+		fmt.Println(answer)
+	}
+	return scanner.Err()
+}
+
+// dialHistory replays day 1's instructions on a dial set up from the puzzle
+// parameters.
+func dialHistory(p map[string]string, inputPath string) (*dial.History, error) {
+	setting := func(key string, def int) (int, error) {
+		v, ok := p[key]
+		if !ok {
+			return def, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q", key, v)
+		}
+		return n, nil
+	}
+
+	size, err := setting("size", 100)
+	if err != nil {
+		return nil, err
+	}
+	start, err := setting("start", 50)
+	if err != nil {
+		return nil, err
+	}
+	target, err := setting("target", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := dial.New(size, start, target)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	program, err := dial.ReadProgram(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return dial.NewHistory(d, program), nil
+}
+
+func dialQuery(h *dial.History, fields []string) (string, error) {
+	nums := make([]int, len(fields)-1)
+	for i, f := range fields[1:] {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return "", fmt.Errorf("invalid instruction number %q", f)
+		}
+		nums[i] = n
+	}
+
+	switch {
+	case fields[0] == "position" && len(nums) == 1:
+		pos, err := h.Position(nums[0])
+		return fmt.Sprintf("Position after %d: %d", nums[0], pos), err
+	case fields[0] == "landings" && len(nums) == 2:
+		n, err := h.Landings(0, nums[0], nums[1])
+		return fmt.Sprintf("Landings in %d-%d: %d", nums[0], nums[1], n), err
+	case fields[0] == "passes" && len(nums) == 2:
+		n, err := h.Passes(0, nums[0], nums[1])
+		return fmt.Sprintf("Passes in %d-%d: %d", nums[0], nums[1], n), err
+	}
+
+	return "", fmt.Errorf("unknown query %q; expected position <i>, landings <i> <j> or passes <i> <j>", strings.Join(fields, " "))
+}
+
+// idQueries sets up day 2's rule and base from the puzzle parameters, with
+// the same defaults as the part's solution, and returns a function answering
+// order queries about them.
+func idQueries(p map[string]string, part int) (func([]string) (string, error), error) {
+	expr, ok := p["rule"]
+	if !ok {
+		expr = "exactly:2"
+		if part == 2 {
+			expr = "atleast:2"
+		}
+	}
+	rule, err := ids.ParseRule(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	base := ids.Decimal
+	if v, ok := p["base"]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base %q", v)
+		}
+		if base, err = ids.NewBase(n); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(fields []string) (string, error) {
+		return idQuery(base, rule, fields)
+	}, nil
+}
+
+// idQuery answers one day 2 query. IDs are read and written in the rule's
+// base; k and counts are decimal.
+func idQuery(base ids.Base, rule ids.Rule, fields []string) (string, error) {
+	if len(fields) < 2 {
+		return "", fmt.Errorf("unknown query %q; expected next <n>, prev <n>, kth <k> or count <a> <b>", strings.Join(fields, " "))
+	}
+	radix := int(base)
+	if fields[0] == "kth" {
+		radix = 10
+	}
+	nums := make([]*big.Int, len(fields)-1)
+	for i, f := range fields[1:] {
+		n, ok := new(big.Int).SetString(f, radix)
+		if !ok {
+			return "", fmt.Errorf("invalid number %q", f)
+		}
+		nums[i] = n
+	}
+
+	switch {
+	case fields[0] == "next" && len(nums) == 1:
+		n, err := base.Next(nums[0], rule)
+		return idAnswer("Next invalid ID from "+fields[1], base, n, err)
+	case fields[0] == "prev" && len(nums) == 1:
+		n, err := base.Previous(nums[0], rule)
+		return idAnswer("Previous invalid ID before "+fields[1], base, n, err)
+	case fields[0] == "kth" && len(nums) == 1:
+		n, err := base.Kth(nums[0], rule)
+		return idAnswer("Invalid ID number "+fields[1], base, n, err)
+	case fields[0] == "count" && len(nums) == 2:
+		n, err := base.Count(nums[0], nums[1], rule)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Invalid IDs in %s-%s: %s", fields[1], fields[2], n), nil
+	}
+
+	return "", fmt.Errorf("unknown query %q; expected next <n>, prev <n>, kth <k> or count <a> <b>", strings.Join(fields, " "))
+}
+
+func idAnswer(label string, base ids.Base, n *big.Int, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s: %s", label, base.Format(n)), nil
+}
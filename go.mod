@@ -0,0 +1,3 @@
+module github.com/dfryer1193/AoC-2025
+
+go 1.25.5
@@ -0,0 +1,137 @@
+// Package anim records the evolving state of a puzzle as a sequence of text
+// frames and writes them out as an animated GIF and/or an asciinema cast.
+package anim
+
+import (
+	"errors"
+	"flag"
+	"math"
+	"strings"
+	"time"
+)
+
+// Options holds the output settings for a recording.
+type Options struct {
+	GIF   string        // path of the animated GIF to write, empty to skip
+	Cast  string        // path of the asciinema cast to write, empty to skip
+	Delay time.Duration // time between frames
+	Scale int           // GIF pixels per grid cell
+}
+
+// RegisterFlags adds the -gif, -cast, -delay and -scale flags to the default
+// flag set and returns the Options they populate.
+func RegisterFlags() *Options {
+	o := &Options{}
+	flag.StringVar(&o.GIF, "gif", "", "write an animated GIF of the run to this file")
+	flag.StringVar(&o.Cast, "cast", "", "write an asciinema cast of the run to this file")
+	flag.DurationVar(&o.Delay, "delay", 100*time.Millisecond, "delay between animation frames")
+	flag.IntVar(&o.Scale, "scale", 4, "GIF pixels per grid cell")
+	return o
+}
+
+// encoder writes frames to a single output file.
+type encoder interface {
+	writeFrame(lines []string, caption string) error
+	close() error
+}
+
+// Recorder fans frames out to every configured encoder. A Recorder with no
+// encoders discards everything, so callers can record unconditionally.
+type Recorder struct {
+	encoders []encoder
+}
+
+// Open creates a Recorder for the outputs requested in o.
+func (o *Options) Open(title string) (*Recorder, error) {
+	r := &Recorder{}
+	if o.Scale < 1 {
+		return nil, errors.New("anim: scale must be at least 1")
+	}
+
+	if o.GIF != "" {
+		e, err := newGIFEncoder(o.GIF, o.Delay, o.Scale)
+		if err != nil {
+			return nil, err
+		}
+		r.encoders = append(r.encoders, e)
+	}
+
+	if o.Cast != "" {
+		e, err := newCastEncoder(o.Cast, title, o.Delay)
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		r.encoders = append(r.encoders, e)
+	}
+
+	return r, nil
+}
+
+// Enabled reports whether any output was requested, so callers can skip
+// building frames nobody will see.
+func (r *Recorder) Enabled() bool {
+	return len(r.encoders) > 0
+}
+
+// Frame appends a frame. Each line is one row of the grid; the caption is
+// shown above the grid in the cast and omitted from the GIF.
+func (r *Recorder) Frame(lines []string, caption string) error {
+	for _, e := range r.encoders {
+		if err := e.writeFrame(lines, caption); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finalizes every output file.
+func (r *Recorder) Close() error {
+	var errs []error
+	for _, e := range r.encoders {
+		errs = append(errs, e.close())
+	}
+	r.encoders = nil
+	return errors.Join(errs...)
+}
+
+// Ring lays n positions out clockwise on a circle, starting at the top, and
+// returns it as grid lines. Every position is drawn as 'o' unless marks holds
+// a different rune for it.
+func Ring(n int, marks map[int]rune) []string {
+	radius := int(math.Ceil(1.5 * float64(n) / (2 * math.Pi)))
+	if radius < 2 {
+		radius = 2
+	}
+
+	side := 2*radius + 1
+	grid := make([][]rune, side)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", side))
+	}
+
+	// Plain positions first so marks are never hidden by a neighbor that
+	// rounds onto the same cell.
+	for pass := 0; pass < 2; pass++ {
+		for i := 0; i < n; i++ {
+			c, marked := marks[i]
+			if marked != (pass == 1) {
+				continue
+			}
+			if !marked {
+				c = 'o'
+			}
+
+			angle := 2*math.Pi*float64(i)/float64(n) - math.Pi/2
+			x := radius + int(math.Round(float64(radius)*math.Cos(angle)))
+			y := radius + int(math.Round(float64(radius)*math.Sin(angle)))
+			grid[y][x] = c
+		}
+	}
+
+	lines := make([]string, side)
+	for i, row := range grid {
+		lines[i] = string(row)
+	}
+	return lines
+}
@@ -0,0 +1,83 @@
+package anim
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+)
+
+const minCastWidth = 60
+
+// castEncoder writes an asciinema v2 recording: a JSON header line followed by
+// one JSON event per frame.
+type castEncoder struct {
+	f     *os.File
+	w     *bufio.Writer
+	title string
+	delay time.Duration
+
+	frames int
+}
+
+func newCastEncoder(path, title string, delay time.Duration) (*castEncoder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &castEncoder{f: f, w: bufio.NewWriter(f), title: title, delay: delay}, nil
+}
+
+func (e *castEncoder) writeFrame(lines []string, caption string) error {
+	if e.frames == 0 {
+		// The terminal size is fixed by the first frame, plus a row for the
+		// caption. Later captions tend to grow, so leave them some room.
+		width := max(len([]rune(caption)), minCastWidth)
+		for _, l := range lines {
+			if n := len([]rune(l)); n > width {
+				width = n
+			}
+		}
+
+		header, err := json.Marshal(map[string]any{
+			"version":   2,
+			"width":     width,
+			"height":    len(lines) + 1,
+			"timestamp": time.Now().Unix(),
+			"title":     e.title,
+		})
+		if err != nil {
+			return err
+		}
+		e.w.Write(header)
+		e.w.WriteByte('\n')
+	}
+
+	// Clear the screen and home the cursor before redrawing
+	var sb strings.Builder
+	sb.WriteString("\x1b[2J\x1b[H")
+	sb.WriteString(caption)
+	for _, l := range lines {
+		sb.WriteString("\r\n")
+		sb.WriteString(l)
+	}
+
+	at := (time.Duration(e.frames) * e.delay).Seconds()
+	event, err := json.Marshal([]any{at, "o", sb.String()})
+	if err != nil {
+		return err
+	}
+	e.w.Write(event)
+	e.frames++
+	return e.w.WriteByte('\n')
+}
+
+func (e *castEncoder) close() error {
+	if err := e.w.Flush(); err != nil {
+		e.f.Close()
+		return err
+	}
+	return e.f.Close()
+}
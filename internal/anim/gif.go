@@ -0,0 +1,202 @@
+package anim
+
+import (
+	"bufio"
+	"compress/lzw"
+	"errors"
+	"image/color"
+	"os"
+	"time"
+)
+
+// palette maps grid runes to GIF colors. Index 0 is the background and is
+// also used for any rune not listed in cellColors.
+var palette = []color.RGBA{
+	{0x0f, 0x0f, 0x23, 0xff}, // background
+	{0x2a, 0x2a, 0x4a, 0xff}, // empty floor
+	{0xff, 0xb0, 0x00, 0xff}, // box / pointer
+	{0xe0, 0x40, 0x40, 0xff}, // removed this step
+	{0x7f, 0x7f, 0xff, 0xff}, // splitter
+	{0x00, 0xcc, 0x00, 0xff}, // beam
+	{0xff, 0xff, 0xff, 0xff}, // start / zero mark
+	{0x66, 0x66, 0x88, 0xff}, // dial position
+	{0xcc, 0xcc, 0xcc, 0xff}, // wall
+}
+
+var cellColors = map[rune]byte{
+	'.': 1,
+	'@': 2,
+	'x': 3,
+	'^': 4,
+	'|': 5,
+	'S': 6,
+	'0': 6,
+	'o': 7,
+	'#': 8,
+}
+
+// gifLitWidth is the LZW literal width, i.e. log2 of the global color table
+// size. The table is padded out to 1<<gifLitWidth entries.
+const gifLitWidth = 4
+
+// gifEncoder streams frames straight into the output file, so long runs never
+// need every frame in memory at once the way image/gif.EncodeAll does.
+type gifEncoder struct {
+	f     *os.File
+	w     *bufio.Writer
+	delay int // centiseconds
+	scale int
+
+	width, height int // in pixels, fixed by the first frame
+	pixels        []byte
+}
+
+func newGIFEncoder(path string, delay time.Duration, scale int) (*gifEncoder, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := int(delay / (10 * time.Millisecond))
+	if cs < 2 {
+		cs = 2 // Most viewers ignore anything faster
+	}
+
+	return &gifEncoder{f: f, w: bufio.NewWriter(f), delay: cs, scale: scale}, nil
+}
+
+func (e *gifEncoder) writeFrame(lines []string, _ string) error {
+	if e.pixels == nil {
+		cols := 0
+		for _, l := range lines {
+			if n := len([]rune(l)); n > cols {
+				cols = n
+			}
+		}
+		e.width, e.height = cols*e.scale, len(lines)*e.scale
+		if e.width == 0 || e.height == 0 {
+			return errors.New("anim: first frame is empty")
+		}
+		if e.width > 0xffff || e.height > 0xffff {
+			return errors.New("anim: frame too large for GIF")
+		}
+		e.pixels = make([]byte, e.width*e.height)
+		e.writeHeader()
+	}
+
+	// Rasterize, clipping or padding to the size fixed by the first frame.
+	clear(e.pixels)
+	for y, l := range lines {
+		if y*e.scale >= e.height {
+			break
+		}
+		for x, c := range []rune(l) {
+			if x*e.scale >= e.width {
+				break
+			}
+			idx := cellColors[c]
+			for dy := 0; dy < e.scale; dy++ {
+				row := (y*e.scale + dy) * e.width
+				for dx := 0; dx < e.scale; dx++ {
+					e.pixels[row+x*e.scale+dx] = idx
+				}
+			}
+		}
+	}
+
+	// Graphic control extension carrying the frame delay
+	e.w.Write([]byte{0x21, 0xf9, 0x04, 0x00, byte(e.delay), byte(e.delay >> 8), 0x00, 0x00})
+
+	// Image descriptor covering the whole screen, no local color table
+	e.w.WriteByte(0x2c)
+	writeUint16(e.w, 0)
+	writeUint16(e.w, 0)
+	writeUint16(e.w, e.width)
+	writeUint16(e.w, e.height)
+	e.w.WriteByte(0x00)
+
+	e.w.WriteByte(gifLitWidth)
+	bw := &blockWriter{w: e.w}
+	lw := lzw.NewWriter(bw, lzw.LSB, gifLitWidth)
+	if _, err := lw.Write(e.pixels); err != nil {
+		return err
+	}
+	if err := lw.Close(); err != nil {
+		return err
+	}
+	return bw.close()
+}
+
+func (e *gifEncoder) writeHeader() {
+	e.w.WriteString("GIF89a")
+	writeUint16(e.w, e.width)
+	writeUint16(e.w, e.height)
+	// Global color table present, 8 bits per channel, 1<<gifLitWidth entries
+	e.w.Write([]byte{0x80 | 0x70 | (gifLitWidth - 1), 0x00, 0x00})
+	for i := 0; i < 1<<gifLitWidth; i++ {
+		var c color.RGBA
+		if i < len(palette) {
+			c = palette[i]
+		}
+		e.w.Write([]byte{c.R, c.G, c.B})
+	}
+
+	// NETSCAPE2.0 application extension: loop forever
+	e.w.Write([]byte{0x21, 0xff, 0x0b})
+	e.w.WriteString("NETSCAPE2.0")
+	e.w.Write([]byte{0x03, 0x01, 0x00, 0x00, 0x00})
+}
+
+func (e *gifEncoder) close() error {
+	if e.pixels != nil {
+		e.w.WriteByte(0x3b) // Trailer
+	}
+	if err := e.w.Flush(); err != nil {
+		e.f.Close()
+		return err
+	}
+	return e.f.Close()
+}
+
+func writeUint16(w *bufio.Writer, v int) {
+	w.WriteByte(byte(v))
+	w.WriteByte(byte(v >> 8))
+}
+
+// blockWriter splits LZW output into the length-prefixed sub-blocks of at
+// most 255 bytes that GIF image data is stored in.
+type blockWriter struct {
+	w   *bufio.Writer
+	buf [255]byte
+	n   int
+}
+
+func (b *blockWriter) Write(p []byte) (int, error) {
+	for _, c := range p {
+		b.buf[b.n] = c
+		b.n++
+		if b.n == len(b.buf) {
+			if err := b.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(p), nil
+}
+
+func (b *blockWriter) flush() error {
+	if b.n == 0 {
+		return nil
+	}
+	b.w.WriteByte(byte(b.n))
+	_, err := b.w.Write(b.buf[:b.n])
+	b.n = 0
+	return err
+}
+
+func (b *blockWriter) close() error {
+	if err := b.flush(); err != nil {
+		return err
+	}
+	return b.w.WriteByte(0x00) // Block terminator
+}
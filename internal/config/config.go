@@ -0,0 +1,148 @@
+// Package config loads the project-wide aoc.toml (or aoc.yaml) file that
+// holds runner defaults and per-day puzzle parameters.
+//
+// A file has a global section and one section per day, optionally narrowed
+// to a single part:
+//
+//	[global]
+//	input = "{day}/input.txt"
+//
+//	[day01]
+//	size = 100
+//
+//	[day01.part2]
+//	start = 50
+//
+// Values can be overridden from the environment: AOC_INPUT for global keys,
+// AOC_DAY01_SIZE for day keys and AOC_DAY01_PART2_START for part keys.
+// Underscores in the key part of a variable name stand for dashes.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileNames lists the names searched for, in order, when no path is given.
+var FileNames = []string{"aoc.toml", "aoc.yaml", "aoc.yml"}
+
+// Config holds every section of a configuration file, keyed by section name
+// ("global", "day01", "day01.part2").
+type Config struct {
+	sections map[string]map[string]string
+}
+
+// Load parses the configuration file at path, choosing the syntax from its
+// extension.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var sections map[string]map[string]string
+	switch ext := filepath.Ext(path); ext {
+	case ".toml":
+		sections, err = parseTOML(string(data))
+	case ".yaml", ".yml":
+		sections, err = parseYAML(string(data))
+	default:
+		return nil, fmt.Errorf("config: unsupported file type %q", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &Config{sections: sections}, nil
+}
+
+// Find returns the first of FileNames present in dir.
+func Find(dir string) (string, bool) {
+	for _, name := range FileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Empty returns a Config with no settings, for when no file exists.
+func Empty() *Config {
+	return &Config{sections: map[string]map[string]string{}}
+}
+
+// Global returns a global setting, preferring the AOC_<KEY> environment
+// variable over the file.
+func (c *Config) Global(key string) (string, bool) {
+	if v, ok := os.LookupEnv(envName("AOC", key)); ok {
+		return v, true
+	}
+	v, ok := c.sections["global"][key]
+	return v, ok
+}
+
+// Params returns the puzzle parameters for one part of a day: the day section
+// overlaid with the part section, then with matching environment variables.
+func (c *Config) Params(day, part int) map[string]string {
+	daySection := fmt.Sprintf("day%02d", day)
+	partSection := fmt.Sprintf("%s.part%d", daySection, part)
+
+	params := make(map[string]string)
+	for k, v := range c.sections[daySection] {
+		params[k] = v
+	}
+	for k, v := range c.sections[partSection] {
+		params[k] = v
+	}
+
+	dayPrefix := envName("AOC", daySection) + "_"
+	partPrefix := envName("AOC", partSection) + "_"
+	dayEnv := make(map[string]string)
+	partEnv := make(map[string]string)
+	for _, kv := range os.Environ() {
+		name, value, _ := strings.Cut(kv, "=")
+		if key, ok := strings.CutPrefix(name, partPrefix); ok && key != "" {
+			partEnv[envKey(key)] = value
+		} else if key, ok := strings.CutPrefix(name, dayPrefix); ok && key != "" && !isPartKey(key) {
+			dayEnv[envKey(key)] = value
+		}
+	}
+	for k, v := range dayEnv {
+		params[k] = v
+	}
+	for k, v := range partEnv {
+		params[k] = v
+	}
+
+	return params
+}
+
+// Keys returns the sorted keys of params, for stable command lines.
+func Keys(params map[string]string) []string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func envName(parts ...string) string {
+	name := strings.Join(parts, "_")
+	return strings.ToUpper(strings.NewReplacer("-", "_", ".", "_").Replace(name))
+}
+
+// envKey turns the key part of an environment variable back into a config key.
+func envKey(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
+
+// isPartKey reports whether the key part of a day variable actually belongs
+// to a part section, e.g. the PART2_START of AOC_DAY01_PART2_START.
+func isPartKey(s string) bool {
+	return len(s) > 4 && strings.HasPrefix(s, "PART") && s[4] >= '0' && s[4] <= '9'
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParamsEnvOverrides(t *testing.T) {
+	c, err := parseTOML("[day01]\nsize = 100\nstart = 50\nmax-steps = 9\n[day01.part2]\nstart = 10\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := &Config{sections: c}
+
+	t.Setenv("AOC_DAY01_SIZE", "200")
+	t.Setenv("AOC_DAY01_MAX_STEPS", "12")
+	t.Setenv("AOC_DAY01_PART2_TARGET", "7")
+	t.Setenv("AOC_DAY02_SIZE", "5")
+
+	tests := []struct {
+		part int
+		want map[string]string
+	}{
+		{1, map[string]string{"size": "200", "start": "50", "max-steps": "12"}},
+		{2, map[string]string{"size": "200", "start": "10", "max-steps": "12", "target": "7"}},
+	}
+
+	for _, tt := range tests {
+		if got := cfg.Params(1, tt.part); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Params(1, %d) = %v, want %v", tt.part, got, tt.want)
+		}
+	}
+
+	// A part variable overrides the part section, which overrides the day
+	t.Setenv("AOC_DAY01_START", "1")
+	t.Setenv("AOC_DAY01_PART2_START", "2")
+	if got := cfg.Params(1, 1)["start"]; got != "1" {
+		t.Errorf("part 1 start = %q, want 1", got)
+	}
+	if got := cfg.Params(1, 2)["start"]; got != "2" {
+		t.Errorf("part 2 start = %q, want 2", got)
+	}
+}
+
+func TestGlobalEnvOverride(t *testing.T) {
+	cfg := &Config{sections: sections{"global": {"input": "file.txt"}}}
+	if got, ok := cfg.Global("input"); !ok || got != "file.txt" {
+		t.Errorf("Global(input) = %q, %v, want file.txt", got, ok)
+	}
+
+	t.Setenv("AOC_INPUT", "env.txt")
+	if got, ok := cfg.Global("input"); !ok || got != "env.txt" {
+		t.Errorf("Global(input) = %q, %v, want env.txt", got, ok)
+	}
+	if got, ok := cfg.Global("missing"); ok {
+		t.Errorf("Global(missing) = %q, want nothing", got)
+	}
+}
+
+func TestIsPartKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"PART1_START", true},
+		{"PART2_MAX_STEPS", true},
+		{"PART12_SIZE", true},
+		{"PART", false},
+		{"PARTS", false},
+		{"PARTX_SIZE", false},
+		{"SIZE", false},
+		{"MAX_PART1", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPartKey(tt.key); got != tt.want {
+			t.Errorf("isPartKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Both parsers understand just enough of their format for flat key/value
+// sections: strings, numbers, booleans and lists of those. Every value is
+// kept as the string a solution would receive on its command line, with lists
+// joined by commas.
+
+func parseTOML(src string) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+	section := "global"
+
+	for n, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(stripComment(line))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("line %d: unterminated section header", n+1)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("line %d: empty section name", n+1)
+			}
+			continue
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n+1)
+		}
+
+		key = strings.TrimSpace(key)
+		value, err := parseValue(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		if err := set(sections, section, key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+
+	return sections, nil
+}
+
+func parseYAML(src string) (map[string]map[string]string, error) {
+	sections := map[string]map[string]string{}
+
+	type level struct {
+		indent int
+		name   string
+	}
+	var stack []level
+	listKey := "" // Key whose block list items are currently being read
+	listSection := ""
+
+	for n, line := range strings.Split(src, "\n") {
+		line = strings.TrimRight(stripComment(line), " \t\r")
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs are not allowed for indentation", n+1)
+		}
+		indent := len(line) - len(trimmed)
+
+		if item, ok := strings.CutPrefix(trimmed, "- "); ok {
+			if listKey == "" {
+				return nil, fmt.Errorf("line %d: list item outside of a list", n+1)
+			}
+			value, err := parseValue(strings.TrimSpace(item))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			if prev := sections[listSection][listKey]; prev != "" {
+				value = prev + "," + value
+			}
+			sections[listSection][listKey] = value
+			continue
+		}
+		listKey = ""
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		key, raw, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key: value", n+1)
+		}
+		key = strings.TrimSpace(key)
+		raw = strings.TrimSpace(raw)
+
+		path := make([]string, len(stack))
+		for i, l := range stack {
+			path[i] = l.name
+		}
+		section := strings.Join(path, ".")
+		if section == "" {
+			section = "global"
+		}
+
+		if raw == "" {
+			// Either a nested section or a key followed by a block list. Record
+			// both possibilities; whichever the next lines turn out to be wins.
+			stack = append(stack, level{indent: indent, name: key})
+			listKey, listSection = key, section
+			if err := set(sections, section, key, ""); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n+1, err)
+			}
+			continue
+		}
+
+		value, err := parseValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		if err := set(sections, section, key, value); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+	}
+
+	// Section headers were provisionally recorded as empty keys of their parent
+	for name, keys := range sections {
+		for k, v := range keys {
+			child := k
+			if name != "global" {
+				child = name + "." + k
+			}
+			if _, isSection := sections[child]; isSection && v == "" {
+				delete(keys, k)
+			}
+		}
+	}
+
+	return sections, nil
+}
+
+func set(sections map[string]map[string]string, section, key, value string) error {
+	if key == "" {
+		return fmt.Errorf("empty key")
+	}
+	if sections[section] == nil {
+		sections[section] = map[string]string{}
+	}
+	if _, dup := sections[section][key]; dup {
+		return fmt.Errorf("duplicate key %q in [%s]", key, section)
+	}
+	sections[section][key] = value
+	return nil
+}
+
+// parseValue turns a scalar or a [a, b, c] list into its command-line form.
+func parseValue(raw string) (string, error) {
+	if raw == "" {
+		return "", fmt.Errorf("missing value")
+	}
+
+	if strings.HasPrefix(raw, "[") {
+		if !strings.HasSuffix(raw, "]") {
+			return "", fmt.Errorf("unterminated list %s", raw)
+		}
+		items := splitList(raw[1 : len(raw)-1])
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			v, err := parseScalar(item)
+			if err != nil {
+				return "", err
+			}
+			values = append(values, v)
+		}
+		return strings.Join(values, ","), nil
+	}
+
+	return parseScalar(raw)
+}
+
+func parseScalar(raw string) (string, error) {
+	switch raw[0] {
+	case '"':
+		v, err := strconv.Unquote(raw)
+		if err != nil {
+			return "", fmt.Errorf("bad string %s", raw)
+		}
+		return v, nil
+	case '\'':
+		if len(raw) < 2 || raw[len(raw)-1] != '\'' {
+			return "", fmt.Errorf("bad string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	}
+
+	return raw, nil
+}
+
+// splitList splits on commas that are not inside quotes.
+func splitList(s string) []string {
+	var items []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			items = append(items, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(items, s[start:])
+}
+
+// stripComment drops a trailing # comment that is not inside quotes.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
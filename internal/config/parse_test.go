@@ -0,0 +1,141 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type sections = map[string]map[string]string
+
+func TestParseTOML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want sections
+	}{
+		{"empty", "", sections{}},
+		{"global keys", `input = "{day}/input.txt"`, sections{"global": {"input": "{day}/input.txt"}}},
+		{
+			"day and part sections",
+			"[day01]\nsize = 100\n\n[day01.part2]\nstart = 50\n",
+			sections{"day01": {"size": "100"}, "day01.part2": {"start": "50"}},
+		},
+		{
+			"explicit global after a day",
+			"[day01]\nsize = 100\n[global]\ntimeout = 5\n",
+			sections{"day01": {"size": "100"}, "global": {"timeout": "5"}},
+		},
+		{
+			"scalars",
+			"a = 'single'\nb = \"dou\\\"ble\"\nc = true\nd = -3.5\n",
+			sections{"global": {"a": "single", "b": `dou"ble`, "c": "true", "d": "-3.5"}},
+		},
+		{
+			"lists",
+			"targets = [0, 50, 99]\nnames = [\"a,b\", 'c', ]\nnone = []\n",
+			sections{"global": {"targets": "0,50,99", "names": "a,b,c", "none": ""}},
+		},
+		{
+			"comments",
+			"# heading\n[day02] # the IDs\nrule = \"atleast:2 # not a comment\" # a comment\n",
+			sections{"day02": {"rule": "atleast:2 # not a comment"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTOML(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseTOML(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseYAML(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want sections
+	}{
+		{"empty", "---\n", sections{}},
+		{"global keys", "input: '{day}/input.txt'\n", sections{"global": {"input": "{day}/input.txt"}}},
+		{
+			"nested sections",
+			"day01:\n  size: 100\n  part2:\n    start: 50\n  step: 3\nday02:\n  base: 16\n",
+			sections{
+				"global":      {},
+				"day01":       {"size": "100", "step": "3"},
+				"day01.part2": {"start": "50"},
+				"day02":       {"base": "16"},
+			},
+		},
+		{
+			"block list",
+			"day01:\n  targets:\n    - 0\n    - \"50\"\n  size: 100\n",
+			sections{"global": {}, "day01": {"targets": "0,50", "size": "100"}},
+		},
+		{
+			"flow list",
+			"day01:\n  targets: [0, 50]\n",
+			sections{"global": {}, "day01": {"targets": "0,50"}},
+		},
+		{
+			"comments",
+			"# heading\nday02: # the IDs\n  rule: 'atleast:2 # not a comment' # a comment\n",
+			sections{"global": {}, "day02": {"rule": "atleast:2 # not a comment"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseYAML(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseYAML(%q) = %v, want %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		parse func(string) (sections, error)
+		src   string
+		want  string
+	}{
+		{"toml unterminated header", parseTOML, "[day01\n", "line 1: unterminated section header"},
+		{"toml empty header", parseTOML, "[ ]\n", "line 1: empty section name"},
+		{"toml no equals", parseTOML, "[day01]\nsize 100\n", "line 2: expected key = value"},
+		{"toml missing value", parseTOML, "size =\n", "line 1: missing value"},
+		{"toml empty key", parseTOML, "= 3\n", "line 1: empty key"},
+		{"toml duplicate key", parseTOML, "size = 1\nsize = 2\n", `line 2: duplicate key "size" in [global]`},
+		{"toml unterminated list", parseTOML, "targets = [1, 2\n", "line 1: unterminated list [1, 2"},
+		{"toml bad string", parseTOML, "name = \"open\n", "line 1: bad string \"open"},
+		{"toml bad single-quoted string", parseTOML, "name = '\n", "line 1: bad string '"},
+		{"yaml tab indent", parseYAML, "day01:\n\tsize: 1\n", "line 2: tabs are not allowed for indentation"},
+		{"yaml stray list item", parseYAML, "- 1\n", "line 1: list item outside of a list"},
+		{"yaml no colon", parseYAML, "day01:\n  size 100\n", "line 2: expected key: value"},
+		{"yaml duplicate key", parseYAML, "day01:\n  size: 1\n  size: 2\n", `line 3: duplicate key "size" in [day01]`},
+		{"yaml bad list item", parseYAML, "targets:\n  - 'open\n", "line 2: bad string 'open"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.parse(tt.src)
+			if err == nil {
+				t.Fatalf("parsed %q as %v, want error %q", tt.src, got, tt.want)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parsing %q: error %q, want %q", tt.src, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Package parse holds the error type every day's input parser reports, so bad
+// input surfaces as a readable error rather than a panic.
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// Error describes input that a parser rejected.
+type Error struct {
+	Line int    // 1-based line number, 0 when it doesn't apply
+	Text string // the offending line or token
+	Err  error  // what was wrong with it
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %v: %q", e.Line, e.Err, e.Text)
+	}
+	return fmt.Sprintf("%v: %q", e.Err, e.Text)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf returns an *Error for line and text with a message formatted like
+// fmt.Errorf, so %w can wrap an underlying error.
+func Errorf(line int, text string, format string, args ...any) error {
+	return &Error{Line: line, Text: text, Err: fmt.Errorf(format, args...)}
+}
+
+// Int parses a base-10 integer token from the given line.
+func Int(line int, text string) (int, error) {
+	v, err := strconv.Atoi(text)
+	if err != nil {
+		var numErr *strconv.NumError
+		if errors.As(err, &numErr) {
+			err = numErr.Err // The text is already in the Error
+		}
+		return 0, Errorf(line, text, "bad number: %w", err)
+	}
+	return v, nil
+}
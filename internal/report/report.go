@@ -0,0 +1,102 @@
+// Package report writes records out as they are produced, so a report on a
+// long run costs no memory. A path ending in .json gets a JSON array of
+// records, one per line; anything else, including "-" for stdout, gets CSV
+// with a header row.
+package report
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Writer streams records to a file or stdout.
+type Writer struct {
+	out  io.WriteCloser
+	w    *bufio.Writer
+	csv  *csv.Writer // nil when writing JSON
+	rows int
+}
+
+// Create starts a report at path. header names the CSV columns.
+func Create(path string, header ...string) (*Writer, error) {
+	var out io.WriteCloser = os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		out = f
+	}
+
+	w := &Writer{out: out, w: bufio.NewWriter(out)}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		w.w.WriteString("[")
+		return w, nil
+	}
+
+	w.csv = csv.NewWriter(w.w)
+	w.csv.Write(header)
+	return w, nil
+}
+
+// JSON reports whether the report is written as JSON.
+func (w *Writer) JSON() bool {
+	return w.csv == nil
+}
+
+// Row writes one CSV record.
+func (w *Writer) Row(fields ...string) error {
+	return w.csv.Write(fields)
+}
+
+// Item writes v as the next JSON record.
+func (w *Writer) Item(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = w.Begin().Write(data)
+	return err
+}
+
+// Begin starts the next JSON record and returns where to write it, for
+// records streamed in pieces rather than marshalled whole.
+func (w *Writer) Begin() *bufio.Writer {
+	if w.rows > 0 {
+		w.w.WriteString(",")
+	}
+	w.rows++
+	w.w.WriteString("\n  ")
+	return w.w
+}
+
+// Close finishes the report, closing its file unless it went to stdout.
+func (w *Writer) Close() error {
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			w.closeFile()
+			return err
+		}
+	} else {
+		w.w.WriteString("\n]\n")
+	}
+
+	if err := w.w.Flush(); err != nil {
+		w.closeFile()
+		return err
+	}
+	return w.closeFile()
+}
+
+func (w *Writer) closeFile() error {
+	if w.out == os.Stdout {
+		return nil
+	}
+	return w.out.Close()
+}
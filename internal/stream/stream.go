@@ -0,0 +1,200 @@
+// Package stream opens puzzle input under a declared memory budget.
+//
+// Each solution says up front how much of its input it keeps with a Mode.
+// Streaming solutions run in memory that does not grow with the input, so
+// they can chew through generated inputs far larger than RAM. Solutions that
+// need the whole input say so, and refuse inputs that cannot fit the budget
+// instead of thrashing.
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Mode declares how much of its input a solution holds at once.
+type Mode int
+
+const (
+	// Lines solutions look at one record at a time, keeping at most a
+	// summary whose size does not depend on the number of records.
+	Lines Mode = iota
+	// WholeInput solutions keep every record in memory.
+	WholeInput
+)
+
+// defaultMaxToken caps the length of a single line or record when no budget
+// is set. bufio's own default of 64KiB is too small for long generated lines.
+const defaultMaxToken = 1 << 20
+
+// Budget is a memory limit in bytes, 0 meaning unlimited. It implements
+// flag.Value, accepting sizes like 512KiB, 64MiB or 2GB.
+type Budget struct {
+	bytes int64
+}
+
+// RegisterFlags adds the -mem-budget flag to the default flag set. Its
+// default comes from $AOC_MEM_BUDGET, which is how the aoc runner passes it on.
+func RegisterFlags() *Budget {
+	b := &Budget{}
+	if env := os.Getenv("AOC_MEM_BUDGET"); env != "" {
+		if err := b.Set(env); err != nil {
+			fmt.Fprintln(os.Stderr, "Ignoring AOC_MEM_BUDGET:", err)
+		}
+	}
+	flag.Var(b, "mem-budget", "memory budget such as 64MiB; whole-input solutions refuse larger inputs (default $AOC_MEM_BUDGET, else unlimited)")
+	return b
+}
+
+func (b *Budget) String() string {
+	if b == nil || b.bytes == 0 {
+		return "unlimited"
+	}
+	return formatSize(b.bytes)
+}
+
+func (b *Budget) Set(s string) error {
+	n, err := parseSize(s)
+	if err != nil {
+		return err
+	}
+	b.bytes = n
+	return nil
+}
+
+// Open opens the input at path for a solution running in the given mode. With
+// a budget set it becomes the runtime's soft memory limit, and a WholeInput
+// solution is refused any input larger than the budget.
+func (b *Budget) Open(path string, mode Mode) (*os.File, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if b.bytes == 0 {
+		return f, nil
+	}
+
+	debug.SetMemoryLimit(b.bytes)
+
+	if mode == WholeInput {
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if info.Mode().IsRegular() && info.Size() > b.bytes {
+			f.Close()
+			return nil, fmt.Errorf("this solution keeps its whole input in memory: %s is %s, over the %s budget",
+				path, formatSize(info.Size()), formatSize(b.bytes))
+		}
+	}
+
+	return f, nil
+}
+
+// Scanner returns a line scanner over r whose longest line fits the budget.
+func (b *Budget) Scanner(r io.Reader) *bufio.Scanner {
+	maxToken := defaultMaxToken
+	if b.bytes > 0 {
+		maxToken = int(min(b.bytes/4, int64(^uint(0)>>1)))
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(maxToken, bufio.MaxScanTokenSize)), maxToken)
+	return scanner
+}
+
+// Records splits input into records separated by commas or newlines, for
+// inputs like day 02's that pack every record onto one long line.
+type Records struct {
+	*bufio.Scanner
+
+	line      int // line the scanner has reached
+	tokenLine int // line the current record came from
+}
+
+// NewRecords switches scanner to splitting on commas as well as newlines.
+// Empty records, from trailing commas or blank lines, are skipped.
+func NewRecords(scanner *bufio.Scanner) *Records {
+	r := &Records{Scanner: scanner, line: 1}
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance := 0
+		for {
+			rest := data[advance:]
+			i := bytes.IndexAny(rest, ",\n")
+			if i == -1 {
+				token := bytes.TrimSpace(rest)
+				if atEOF && len(token) > 0 {
+					r.tokenLine = r.line
+					return len(data), token, nil
+				}
+				if atEOF {
+					return len(data), nil, nil
+				}
+				return advance, nil, nil // Need more data
+			}
+
+			token := bytes.TrimSpace(rest[:i])
+			r.tokenLine = r.line
+			if rest[i] == '\n' {
+				r.line++
+			}
+			advance += i + 1
+			if len(token) > 0 {
+				return advance, token, nil
+			}
+		}
+	})
+	return r
+}
+
+// Line returns the 1-based line the current record came from.
+func (r *Records) Line() int {
+	return r.tokenLine
+}
+
+func parseSize(s string) (int64, error) {
+	units := []struct {
+		suffix string
+		scale  int64
+	}{
+		{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"GiB", 1 << 30},
+		{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9},
+		{"K", 1 << 10}, {"M", 1 << 20}, {"G", 1 << 30},
+		{"B", 1},
+	}
+
+	s = strings.TrimSpace(s)
+	scale := int64(1)
+	for _, u := range units {
+		if rest, ok := strings.CutSuffix(s, u.suffix); ok {
+			s, scale = strings.TrimSpace(rest), u.scale
+			break
+		}
+	}
+
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return n * scale, nil
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<30:
+		return fmt.Sprintf("%.1fGiB", float64(n)/(1<<30))
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1fMiB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1fKiB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%dB", n)
+}